@@ -13,6 +13,8 @@ package apiserver
 import (
 	"context"
 	"net/http"
+	"os"
+	"time"
 )
 
 // ConfigurationAPIRouter defines the required methods for binding the api requests to a responses for the ConfigurationAPI
@@ -21,6 +23,10 @@ import (
 type ConfigurationAPIRouter interface {
 	GetConfiguration(http.ResponseWriter, *http.Request)
 	PutConfiguration(http.ResponseWriter, *http.Request)
+	PatchConfiguration(http.ResponseWriter, *http.Request)
+	DeleteConfiguration(http.ResponseWriter, *http.Request)
+	GetConfigurationHistory(http.ResponseWriter, *http.Request)
+	RestoreConfigurationRevision(http.ResponseWriter, *http.Request)
 }
 
 // CustomizationAPIRouter defines the required methods for binding the api requests to a responses for the CustomizationAPI
@@ -30,6 +36,20 @@ type CustomizationAPIRouter interface {
 	GetDashboardTemplateByName(http.ResponseWriter, *http.Request)
 }
 
+// LocationsAPIRouter defines the required methods for binding the api requests to a responses for the LocationsAPI
+// The LocationsAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a LocationsAPIServicer to perform the required actions, then write the service results to the http response.
+type LocationsAPIRouter interface {
+	GetLocations(http.ResponseWriter, *http.Request)
+	PostLocation(http.ResponseWriter, *http.Request)
+	ImportLocations(http.ResponseWriter, *http.Request)
+	GetLocationById(http.ResponseWriter, *http.Request)
+	PutLocationById(http.ResponseWriter, *http.Request)
+	DeleteLocationById(http.ResponseWriter, *http.Request)
+	GetLocationHistory(http.ResponseWriter, *http.Request)
+	ExportWeatherHistory(http.ResponseWriter, *http.Request)
+}
+
 // VersionAPIRouter defines the required methods for binding the api requests to a responses for the VersionAPI
 // The VersionAPIRouter implementation should parse necessary information from the http request,
 // pass the data to a VersionAPIServicer to perform the required actions, then write the service results to the http response.
@@ -38,6 +58,13 @@ type VersionAPIRouter interface {
 	GetOpenAPI(http.ResponseWriter, *http.Request)
 }
 
+// WeatherAPIRouter defines the required methods for binding the api requests to a responses for the WeatherAPI
+// The WeatherAPIRouter implementation should parse necessary information from the http request,
+// pass the data to a WeatherAPIServicer to perform the required actions, then write the service results to the http response.
+type WeatherAPIRouter interface {
+	GetWeather(http.ResponseWriter, *http.Request)
+}
+
 // ConfigurationAPIServicer defines the api actions for the ConfigurationAPI service
 // This interface intended to stay up to date with the openapi yaml used to generate it,
 // while the service implementation can be ignored with the .openapi-generator-ignore file
@@ -45,6 +72,10 @@ type VersionAPIRouter interface {
 type ConfigurationAPIServicer interface {
 	GetConfiguration(context.Context) (ImplResponse, error)
 	PutConfiguration(context.Context, Configuration) (ImplResponse, error)
+	PatchConfiguration(context.Context, map[string]interface{}) (ImplResponse, error)
+	DeleteConfiguration(context.Context, bool) (ImplResponse, error)
+	GetConfigurationHistory(context.Context) (ImplResponse, error)
+	RestoreConfigurationRevision(context.Context, int64) (ImplResponse, error)
 }
 
 // CustomizationAPIServicer defines the api actions for the CustomizationAPI service
@@ -55,6 +86,21 @@ type CustomizationAPIServicer interface {
 	GetDashboardTemplateByName(context.Context, string, string) (ImplResponse, error)
 }
 
+// LocationsAPIServicer defines the api actions for the LocationsAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type LocationsAPIServicer interface {
+	GetLocations(context.Context, string) (ImplResponse, error)
+	PostLocation(context.Context, Location) (ImplResponse, error)
+	ImportLocations(context.Context, bool, *os.File) (ImplResponse, error)
+	GetLocationById(context.Context, int64) (ImplResponse, error)
+	PutLocationById(context.Context, int64, Location) (ImplResponse, error)
+	DeleteLocationById(context.Context, int64) (ImplResponse, error)
+	GetLocationHistory(context.Context, int64, time.Time, time.Time, []string, string, string) (ImplResponse, error)
+	ExportWeatherHistory(context.Context, []int64, time.Time, time.Time, []string, string, string, string) (ImplResponse, error)
+}
+
 // VersionAPIServicer defines the api actions for the VersionAPI service
 // This interface intended to stay up to date with the openapi yaml used to generate it,
 // while the service implementation can be ignored with the .openapi-generator-ignore file
@@ -63,3 +109,11 @@ type VersionAPIServicer interface {
 	GetVersion(context.Context) (ImplResponse, error)
 	GetOpenAPI(context.Context) (ImplResponse, error)
 }
+
+// WeatherAPIServicer defines the api actions for the WeatherAPI service
+// This interface intended to stay up to date with the openapi yaml used to generate it,
+// while the service implementation can be ignored with the .openapi-generator-ignore file
+// and updated with the logic required for the API.
+type WeatherAPIServicer interface {
+	GetWeather(context.Context, *float64, *float64, string, bool) (ImplResponse, error)
+}
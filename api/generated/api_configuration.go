@@ -16,6 +16,8 @@ import (
 	"io"
 	"net/http"
 	"strings"
+
+	"github.com/gorilla/mux"
 )
 
 // ConfigurationAPIController binds http requests to an api service and writes the service results to the http response
@@ -61,6 +63,26 @@ func (c *ConfigurationAPIController) Routes() Routes {
 			"/v1/configs",
 			c.PutConfiguration,
 		},
+		"PatchConfiguration": Route{
+			strings.ToUpper("Patch"),
+			"/v1/configs",
+			c.PatchConfiguration,
+		},
+		"DeleteConfiguration": Route{
+			strings.ToUpper("Delete"),
+			"/v1/configs",
+			c.DeleteConfiguration,
+		},
+		"GetConfigurationHistory": Route{
+			strings.ToUpper("Get"),
+			"/v1/configs/history",
+			c.GetConfigurationHistory,
+		},
+		"RestoreConfigurationRevision": Route{
+			strings.ToUpper("Post"),
+			"/v1/configs/history/{revision-id}/restore",
+			c.RestoreConfigurationRevision,
+		},
 	}
 }
 
@@ -102,3 +124,88 @@ func (c *ConfigurationAPIController) PutConfiguration(w http.ResponseWriter, r *
 	// If no error, encode the body and the result code
 	_ = EncodeJSONResponse(result.Body, &result.Code, w)
 }
+
+// PatchConfiguration - Partially updates the configuration
+func (c *ConfigurationAPIController) PatchConfiguration(w http.ResponseWriter, r *http.Request) {
+	var bodyParam map[string]interface{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&bodyParam); err != nil && !errors.Is(err, io.EOF) {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.PatchConfiguration(r.Context(), bodyParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// DeleteConfiguration - Deletes the configuration
+func (c *ConfigurationAPIController) DeleteConfiguration(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var deleteAssetsParam bool
+	if query.Has("deleteAssets") {
+		param, err := parseBoolParameter(
+			query.Get("deleteAssets"),
+			WithParse[bool](parseBool),
+		)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "deleteAssets", Err: err}, nil)
+			return
+		}
+
+		deleteAssetsParam = param
+	} else {
+		var param bool = false
+		deleteAssetsParam = param
+	}
+	result, err := c.service.DeleteConfiguration(r.Context(), deleteAssetsParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// GetConfigurationHistory - Get configuration history
+func (c *ConfigurationAPIController) GetConfigurationHistory(w http.ResponseWriter, r *http.Request) {
+	result, err := c.service.GetConfigurationHistory(r.Context())
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
+
+// RestoreConfigurationRevision - Restore a configuration revision
+func (c *ConfigurationAPIController) RestoreConfigurationRevision(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	revisionIdParam, err := parseNumericParameter[int64](
+		params["revision-id"],
+		WithRequire[int64](parseInt64),
+	)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Param: "revision-id", Err: err}, nil)
+		return
+	}
+	result, err := c.service.RestoreConfigurationRevision(r.Context(), revisionIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(result.Body, &result.Code, w)
+}
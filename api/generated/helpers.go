@@ -14,6 +14,7 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"mime"
 	"mime/multipart"
 	"net/http"
 	"net/url"
@@ -74,10 +75,36 @@ func AssertRecurseValueRequired[T any](value reflect.Value, callback func(T) err
 	return nil
 }
 
+// StreamResponse is a response body that is written to the response while it is produced, e.g. a large
+// download, instead of being encoded at once.
+type StreamResponse struct {
+	ContentType string
+	// Filename is suggested to the client for saving the body.
+	Filename string
+	// Write writes the body to w. flush sends what was written so far to the client. Once the first part is
+	// sent, an error only truncates the body.
+	Write func(w io.Writer, flush func()) error
+}
+
 // EncodeJSONResponse uses the json encoder to write an interface to the http response with an optional status code
 func EncodeJSONResponse(i interface{}, status *int, w http.ResponseWriter) error {
 	wHeader := w.Header()
 
+	if s, ok := i.(StreamResponse); ok {
+		wHeader.Set("Content-Type", s.ContentType)
+		if s.Filename != "" {
+			wHeader.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": s.Filename}))
+		}
+		if status != nil {
+			w.WriteHeader(*status)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+		rc := http.NewResponseController(w)
+		// Not every wrapping response writer supports flushing; the body is then sent at the end.
+		return s.Write(w, func() { _ = rc.Flush() })
+	}
+
 	f, ok := i.(*os.File)
 	if ok {
 		data, err := io.ReadAll(f)
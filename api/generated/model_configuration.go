@@ -16,26 +16,47 @@ type Configuration struct {
 	// Internal identifier for the configured API (created automatically).
 	Id *int64 `json:"id,omitempty"`
 
-	// There should be things like API address, access keys and so on.
+	// OpenWeatherMap API key. Responses return it masked; sending the masked value back keeps the stored key.
 	ApiKey string `json:"apiKey,omitempty"`
 
 	// Flag to enable or disable fetching from this API
 	Enable *bool `json:"enable,omitempty"`
 
-	// Interval in seconds for collecting data from API
+	// Interval in seconds for collecting data from API. Must be greater than 0.
 	RefreshInterval int32 `json:"refreshInterval,omitempty"`
 
-	// Timeout in seconds
+	// Timeout in seconds. Must be greater than 0 and not larger than `refreshInterval`, which is also the default.
 	RequestTimeout *int32 `json:"requestTimeout,omitempty"`
 
 	// Set to `true` by the app when running and to `false` when app is stopped
 	Active *bool `json:"active,omitempty"`
 
-	// List of Eliona project ids for which this device should collect data. For each project id all smart devices are automatically created as an asset in Eliona. The mapping between Eliona is stored as an asset mapping in the Weather app.
+	// List of Eliona project ids for which this device should collect data. At least one existing project is required. For each project id all smart devices are automatically created as an asset in Eliona. The mapping between Eliona is stored as an asset mapping in the Weather app.
 	ProjectIDs *[]string `json:"projectIDs,omitempty"`
 
 	// ID of the last Eliona user who created or updated the configuration
 	UserId *string `json:"userId,omitempty"`
+
+	// Number of refresh intervals after which a location without a successful update is stale. 0 disables the check.
+	StaleAfterIntervals *int32 `json:"staleAfterIntervals,omitempty"`
+
+	// Age in seconds of the provider's observation after which a location is stale, e.g. if the provider keeps returning the same data. 0 disables the check.
+	MaxDataAge *int32 `json:"maxDataAge,omitempty"`
+
+	// Send an Eliona notification to the user of the configuration when a location becomes stale.
+	NotifyStale *bool `json:"notifyStale,omitempty"`
+
+	// Limits for weather attributes by attribute name, replacing the app's default limits for these attributes. Values outside the limits are not written to Eliona.
+	PlausibilityLimits *map[string]PlausibilityLimit `json:"plausibilityLimits,omitempty"`
+
+	// Base temperatures for heating and cooling degree days by project ID. Projects without an entry use 20/12 °C for heating (SIA 381/3) and 18 °C for cooling.
+	DegreeDayBases *map[string]DegreeDayBase `json:"degreeDayBases,omitempty"`
+
+	// Rules switching output attributes of each location, by output attribute name. The output attributes are added to the weather asset type.
+	Rules *map[string]OutputRule `json:"rules,omitempty"`
+
+	// Alerts notifying Eliona users when weather attributes cross thresholds, by alert name.
+	Alerts *map[string]Alert `json:"alerts,omitempty"`
 }
 
 // AssertConfigurationRequired checks if the required fields are not zero-ed
@@ -17,12 +17,19 @@ package apiservices
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"regexp"
+	"slices"
+	"strings"
 	apiserver "weather-app2/api/generated"
 	appmodel "weather-app2/app/model"
 	"weather-app2/broker"
 	dbhelper "weather-app2/db/helper"
+	"weather-app2/eliona"
 
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 )
@@ -47,29 +54,389 @@ func (s *ConfigurationAPIService) GetConfiguration(ctx context.Context) (apiserv
 }
 
 func (s *ConfigurationAPIService) PutConfiguration(ctx context.Context, config apiserver.Configuration) (apiserver.ImplResponse, error) {
+	return saveConfiguration(ctx, config, http.StatusCreated)
+}
+
+// PatchConfiguration merges the patch into the stored configuration following JSON merge patch (RFC 7386).
+func (s *ConfigurationAPIService) PatchConfiguration(ctx context.Context, patch map[string]interface{}) (apiserver.ImplResponse, error) {
+	storedConfig, err := dbhelper.GetConfig(ctx)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+
+	// The stored configuration is merged in its API form, so an untouched API key stays masked and is kept.
+	stored, err := json.Marshal(toAPIConfig(storedConfig))
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, fmt.Errorf("marshalling configuration: %v", err)
+	}
+	var merged map[string]interface{}
+	if err := json.Unmarshal(stored, &merged); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, fmt.Errorf("unmarshalling configuration: %v", err)
+	}
+	mergePatch(merged, patch)
+
+	mergedJSON, err := json.Marshal(merged)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, fmt.Errorf("marshalling merged configuration: %v", err)
+	}
+	var config apiserver.Configuration
+	if err := json.Unmarshal(mergedJSON, &config); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("applying patch: %v", err)
+	}
+	return saveConfiguration(ctx, config, http.StatusOK)
+}
+
+// mergePatch applies a JSON merge patch to target. Null values remove the field.
+func mergePatch(target, patch map[string]interface{}) {
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		if patchObject, ok := value.(map[string]interface{}); ok {
+			targetObject, ok := target[key].(map[string]interface{})
+			if !ok {
+				targetObject = map[string]interface{}{}
+			}
+			mergePatch(targetObject, patchObject)
+			target[key] = targetObject
+			continue
+		}
+		target[key] = value
+	}
+}
+
+// DeleteConfiguration deletes the configuration and the location mapping. The collection stops
+// as soon as the app finds no configuration.
+func (s *ConfigurationAPIService) DeleteConfiguration(ctx context.Context, deleteAssets bool) (apiserver.ImplResponse, error) {
+	if deleteAssets {
+		if err := deleteElionaAssets(ctx); err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		}
+	}
+	if err := dbhelper.DeleteAssets(ctx); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, fmt.Errorf("deleting asset mapping: %v", err)
+	}
+	if err := dbhelper.DeleteConfig(ctx); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, fmt.Errorf("deleting configuration: %v", err)
+	}
+	return apiserver.Response(http.StatusNoContent, nil), nil
+}
+
+func (s *ConfigurationAPIService) GetConfigurationHistory(ctx context.Context) (apiserver.ImplResponse, error) {
+	revisions, err := dbhelper.GetConfigHistory(ctx)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	apiRevisions := make([]apiserver.ConfigurationRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		apiRevisions = append(apiRevisions, toAPIConfigRevision(revision))
+	}
+	return apiserver.Response(http.StatusOK, apiRevisions), nil
+}
+
+// RestoreConfigurationRevision saves the configuration as it was after the given change.
+func (s *ConfigurationAPIService) RestoreConfigurationRevision(ctx context.Context, revisionId int64) (apiserver.ImplResponse, error) {
+	revision, err := dbhelper.GetConfigRevision(ctx, revisionId)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	config := revision.Configuration
+	return saveConfiguration(ctx, apiserver.Configuration{
+		ApiKey:          config.ApiKey,
+		Enable:          &config.Enable,
+		RefreshInterval: config.RefreshInterval,
+		RequestTimeout:  &config.RequestTimeout,
+		ProjectIDs:      &config.ProjectIDs,
+
+		StaleAfterIntervals: &config.StaleAfterIntervals,
+		MaxDataAge:          &config.MaxDataAge,
+		NotifyStale:         &config.NotifyStale,
+		PlausibilityLimits:  toAPIPlausibilityLimits(config.PlausibilityLimits),
+		DegreeDayBases:      toAPIDegreeDayBases(config.DegreeDayBases),
+		Rules:               toAPIRules(config.Rules),
+		Alerts:              toAPIAlerts(config.Alerts),
+	}, http.StatusOK)
+}
+
+func toAPIConfigRevision(revision appmodel.ConfigurationRevision) apiserver.ConfigurationRevision {
+	diff := make(map[string]apiserver.ConfigurationChange, len(revision.Diff))
+	for field, change := range revision.Diff {
+		diff[field] = apiserver.ConfigurationChange{Old: change.Old, New: change.New}
+	}
+	return apiserver.ConfigurationRevision{
+		Id:        revision.ID,
+		ChangedAt: revision.ChangedAt,
+		UserId:    revision.UserId,
+		Diff:      diff,
+	}
+}
+
+// deleteElionaAssets deletes the weather assets and the root assets created by the app in Eliona.
+func deleteElionaAssets(ctx context.Context) error {
+	assets, err := dbhelper.GetAssets(ctx)
+	if err != nil && !errors.Is(err, dbhelper.ErrNotFound) {
+		return fmt.Errorf("getting assets: %v", err)
+	}
+	for _, asset := range assets {
+		if err := eliona.DeleteAsset(asset.AssetID); err != nil {
+			return fmt.Errorf("deleting asset %d in Eliona: %v", asset.AssetID, err)
+		}
+	}
+	rootAssets, err := dbhelper.GetRootAssets()
+	if err != nil {
+		return fmt.Errorf("getting root assets: %v", err)
+	}
+	for _, rootAsset := range rootAssets {
+		if err := eliona.DeleteAsset(rootAsset.AssetID); err != nil {
+			return fmt.Errorf("deleting root asset %d in Eliona: %v", rootAsset.AssetID, err)
+		}
+	}
+	return nil
+}
+
+// saveConfiguration validates and stores the configuration, answering with successCode.
+func saveConfiguration(ctx context.Context, config apiserver.Configuration, successCode int) (apiserver.ImplResponse, error) {
 	config.Id = api.PtrInt64(1)
 	appConfig := toAppConfig(config)
+	if dbhelper.IsMaskedSecret(appConfig.ApiKey) {
+		// The client sent back the masked key it got from GET, so keep the stored one.
+		storedConfig, err := dbhelper.GetConfig(ctx)
+		if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("keeping stored API key: %v", err)
+		}
+		appConfig.ApiKey = storedConfig.ApiKey
+	}
+	if config.RequestTimeout == nil {
+		appConfig.RequestTimeout = appConfig.RefreshInterval
+	}
+	fieldErrors, err := validateConfiguration(appConfig)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, fmt.Errorf("validating configuration: %v", err)
+	}
+	if len(fieldErrors) > 0 {
+		return apiserver.Response(http.StatusBadRequest, apiserver.ValidationErrors{Errors: fieldErrors}), nil
+	}
 	if err := broker.TestAuthentication(appConfig); err != nil {
 		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("testing authentication: %v", err)
 	}
 	upsertedConfig, err := dbhelper.UpsertConfig(ctx, appConfig)
-	if err != nil {
+	if errors.Is(err, dbhelper.ErrNoEncryptionKey) {
+		return apiserver.ImplResponse{Code: http.StatusServiceUnavailable}, fmt.Errorf("the configuration can't be saved: %v", err)
+	} else if err != nil {
 		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
 	}
-	return apiserver.Response(http.StatusCreated, toAPIConfig(upsertedConfig)), nil
+	return apiserver.Response(successCode, toAPIConfig(upsertedConfig)), nil
+}
+
+// validateConfiguration returns an error for each invalid field of the configuration.
+func validateConfiguration(config appmodel.Configuration) ([]apiserver.FieldError, error) {
+	var fieldErrors []apiserver.FieldError
+	if config.RefreshInterval <= 0 {
+		fieldErrors = append(fieldErrors, apiserver.FieldError{Field: "refreshInterval", Message: "must be greater than 0"})
+	}
+	if config.RequestTimeout <= 0 {
+		fieldErrors = append(fieldErrors, apiserver.FieldError{Field: "requestTimeout", Message: "must be greater than 0"})
+	} else if config.RefreshInterval > 0 && config.RequestTimeout > config.RefreshInterval {
+		fieldErrors = append(fieldErrors, apiserver.FieldError{Field: "requestTimeout", Message: fmt.Sprintf("must not be larger than refreshInterval (%d)", config.RefreshInterval)})
+	}
+	if config.StaleAfterIntervals < 0 {
+		fieldErrors = append(fieldErrors, apiserver.FieldError{Field: "staleAfterIntervals", Message: "must not be negative"})
+	}
+	if config.MaxDataAge < 0 {
+		fieldErrors = append(fieldErrors, apiserver.FieldError{Field: "maxDataAge", Message: "must not be negative"})
+	}
+	for _, attribute := range slices.Sorted(maps.Keys(config.PlausibilityLimits)) {
+		limit := config.PlausibilityLimits[attribute]
+		field := "plausibilityLimits." + attribute
+		if _, ok := appmodel.DefaultPlausibilityLimits[attribute]; !ok {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: "unknown weather attribute"})
+			continue
+		}
+		if limit.Min != nil && limit.Max != nil && *limit.Min > *limit.Max {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: "min must not be larger than max"})
+		}
+		if limit.MaxChangePerHour != nil && *limit.MaxChangePerHour <= 0 {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: "maxChangePerHour must be greater than 0"})
+		}
+	}
+	if len(config.Rules) > 0 || len(config.Alerts) > 0 {
+		attributes, err := eliona.GetWeatherAttributes()
+		if err != nil {
+			return nil, fmt.Errorf("getting weather attributes: %v", err)
+		}
+		inputs := make(map[string]bool, len(attributes))
+		for _, attribute := range attributes {
+			inputs[attribute.Name] = attribute.GetSubtype() == api.SUBTYPE_INPUT
+		}
+		fieldErrors = append(fieldErrors, validateRules(config.Rules, inputs)...)
+		fieldErrors = append(fieldErrors, validateAlerts(config, inputs)...)
+	}
+	if config.ApiKey == "" {
+		fieldErrors = append(fieldErrors, apiserver.FieldError{Field: "apiKey", Message: "must not be empty"})
+	}
+
+	if len(config.ProjectIDs) == 0 {
+		fieldErrors = append(fieldErrors, apiserver.FieldError{Field: "projectIDs", Message: "must contain at least one project"})
+		return fieldErrors, nil
+	}
+	existingIDs, err := eliona.GetProjectIDs()
+	if err != nil {
+		return nil, fmt.Errorf("getting Eliona projects: %v", err)
+	}
+	for _, projectID := range config.ProjectIDs {
+		if !slices.Contains(existingIDs, projectID) {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: "projectIDs", Message: fmt.Sprintf("project %q does not exist in Eliona", projectID)})
+		}
+	}
+	for _, projectID := range slices.Sorted(maps.Keys(config.DegreeDayBases)) {
+		base := config.DegreeDayBases[projectID]
+		field := "degreeDayBases." + projectID
+		if !slices.Contains(config.ProjectIDs, projectID) {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: "project is not in projectIDs"})
+		}
+		if base.HeatingLimit > base.HeatingBase {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: "heatingLimit must not be larger than heatingBase"})
+		}
+		for _, t := range []float64{base.HeatingBase, base.HeatingLimit, base.CoolingBase} {
+			if t < -30 || t > 40 {
+				fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: "temperatures must be between -30 and 40 °C"})
+				break
+			}
+		}
+	}
+	return fieldErrors, nil
+}
+
+var outputNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// validateRules checks that the rules watch input attributes of the weather asset type and that their
+// outputs don't collide with any of its attributes. inputs tells for each attribute whether it is an input.
+func validateRules(rules map[string]appmodel.Rule, inputs map[string]bool) []apiserver.FieldError {
+	var fieldErrors []apiserver.FieldError
+	for _, output := range slices.Sorted(maps.Keys(rules)) {
+		rule := rules[output]
+		field := "rules." + output
+		if !outputNamePattern.MatchString(output) {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: "output name must consist of lowercase letters, digits and underscores"})
+		} else if _, ok := inputs[output]; ok {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: "output name is a weather attribute"})
+		}
+		if !inputs[rule.Attribute] {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: fmt.Sprintf("unknown weather attribute %q", rule.Attribute)})
+		} else if slices.Contains(appmodel.SolarAttributes, rule.Attribute) {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: fmt.Sprintf("rules cannot watch the solar attribute %q", rule.Attribute)})
+		}
+		if rule.Condition != appmodel.RuleAbove && rule.Condition != appmodel.RuleBelow {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: "condition must be above or below"})
+		}
+		if rule.Hysteresis < 0 {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: "hysteresis must not be negative"})
+		}
+		if rule.MinOnTime < 0 || rule.MinOffTime < 0 {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: "minOnTime and minOffTime must not be negative"})
+		}
+	}
+	return fieldErrors
+}
+
+// validateAlerts checks that the alerts watch input attributes of the weather asset type and are limited
+// to projects of the configuration.
+func validateAlerts(config appmodel.Configuration, inputs map[string]bool) []apiserver.FieldError {
+	var fieldErrors []apiserver.FieldError
+	for _, name := range slices.Sorted(maps.Keys(config.Alerts)) {
+		alert := config.Alerts[name]
+		field := "alerts." + name
+		if strings.TrimSpace(name) == "" {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: "name must not be empty"})
+		}
+		if !inputs[alert.Attribute] {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: fmt.Sprintf("unknown weather attribute %q", alert.Attribute)})
+		} else if slices.Contains(appmodel.SolarAttributes, alert.Attribute) {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: fmt.Sprintf("alerts cannot watch the solar attribute %q", alert.Attribute)})
+		}
+		if alert.Condition != appmodel.RuleAbove && alert.Condition != appmodel.RuleBelow {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: "condition must be above or below"})
+		}
+		if alert.Hysteresis < 0 {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: "hysteresis must not be negative"})
+		}
+		if alert.Cooldown < 0 {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: "cooldown must not be negative"})
+		}
+		for _, projectID := range alert.ProjectIDs {
+			if !slices.Contains(config.ProjectIDs, projectID) {
+				fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: fmt.Sprintf("project %q is not in projectIDs", projectID)})
+			}
+		}
+		if slices.Contains(alert.Users, "") {
+			fieldErrors = append(fieldErrors, apiserver.FieldError{Field: field, Message: "users must not be empty"})
+		}
+	}
+	return fieldErrors
 }
 
+// Defaults of the staleness thresholds, matching the database defaults.
+const (
+	defaultStaleAfterIntervals = 3
+	defaultMaxDataAge          = 3600
+)
+
 func toAPIConfig(appConfig appmodel.Configuration) apiserver.Configuration {
 	return apiserver.Configuration{
 		Id:              &appConfig.Id,
-		ApiKey:          appConfig.ApiKey,
+		ApiKey:          dbhelper.MaskSecret(appConfig.ApiKey),
 		Enable:          &appConfig.Enable,
 		RefreshInterval: appConfig.RefreshInterval,
 		RequestTimeout:  &appConfig.RequestTimeout,
 		Active:          &appConfig.Active,
 		ProjectIDs:      &appConfig.ProjectIDs,
 		UserId:          &appConfig.UserId,
+
+		StaleAfterIntervals: &appConfig.StaleAfterIntervals,
+		MaxDataAge:          &appConfig.MaxDataAge,
+		NotifyStale:         &appConfig.NotifyStale,
+		PlausibilityLimits:  toAPIPlausibilityLimits(appConfig.PlausibilityLimits),
+		DegreeDayBases:      toAPIDegreeDayBases(appConfig.DegreeDayBases),
+		Rules:               toAPIRules(appConfig.Rules),
+		Alerts:              toAPIAlerts(appConfig.Alerts),
+	}
+}
+
+func toAPIPlausibilityLimits(limits map[string]appmodel.PlausibilityLimit) *map[string]apiserver.PlausibilityLimit {
+	apiLimits := make(map[string]apiserver.PlausibilityLimit, len(limits))
+	for attribute, limit := range limits {
+		apiLimits[attribute] = apiserver.PlausibilityLimit(limit)
+	}
+	return &apiLimits
+}
+
+func toAPIDegreeDayBases(bases map[string]appmodel.DegreeDayBase) *map[string]apiserver.DegreeDayBase {
+	apiBases := make(map[string]apiserver.DegreeDayBase, len(bases))
+	for projectID, base := range bases {
+		apiBases[projectID] = apiserver.DegreeDayBase(base)
+	}
+	return &apiBases
+}
+
+func toAPIRules(rules map[string]appmodel.Rule) *map[string]apiserver.OutputRule {
+	apiRules := make(map[string]apiserver.OutputRule, len(rules))
+	for output, rule := range rules {
+		apiRules[output] = apiserver.OutputRule(rule)
 	}
+	return &apiRules
+}
+
+func toAPIAlerts(alerts map[string]appmodel.Alert) *map[string]apiserver.Alert {
+	apiAlerts := make(map[string]apiserver.Alert, len(alerts))
+	for name, alert := range alerts {
+		apiAlerts[name] = apiserver.Alert(alert)
+	}
+	return &apiAlerts
 }
 
 func toAppConfig(apiConfig apiserver.Configuration) (appConfig appmodel.Configuration) {
@@ -92,5 +459,41 @@ func toAppConfig(apiConfig apiserver.Configuration) (appConfig appmodel.Configur
 	if apiConfig.ProjectIDs != nil {
 		appConfig.ProjectIDs = *apiConfig.ProjectIDs
 	}
+
+	appConfig.StaleAfterIntervals = defaultStaleAfterIntervals
+	if apiConfig.StaleAfterIntervals != nil {
+		appConfig.StaleAfterIntervals = *apiConfig.StaleAfterIntervals
+	}
+	appConfig.MaxDataAge = defaultMaxDataAge
+	if apiConfig.MaxDataAge != nil {
+		appConfig.MaxDataAge = *apiConfig.MaxDataAge
+	}
+	if apiConfig.NotifyStale != nil {
+		appConfig.NotifyStale = *apiConfig.NotifyStale
+	}
+	if apiConfig.PlausibilityLimits != nil && len(*apiConfig.PlausibilityLimits) > 0 {
+		appConfig.PlausibilityLimits = make(map[string]appmodel.PlausibilityLimit, len(*apiConfig.PlausibilityLimits))
+		for attribute, limit := range *apiConfig.PlausibilityLimits {
+			appConfig.PlausibilityLimits[attribute] = appmodel.PlausibilityLimit(limit)
+		}
+	}
+	if apiConfig.DegreeDayBases != nil && len(*apiConfig.DegreeDayBases) > 0 {
+		appConfig.DegreeDayBases = make(map[string]appmodel.DegreeDayBase, len(*apiConfig.DegreeDayBases))
+		for projectID, base := range *apiConfig.DegreeDayBases {
+			appConfig.DegreeDayBases[projectID] = appmodel.DegreeDayBase(base)
+		}
+	}
+	if apiConfig.Rules != nil && len(*apiConfig.Rules) > 0 {
+		appConfig.Rules = make(map[string]appmodel.Rule, len(*apiConfig.Rules))
+		for output, rule := range *apiConfig.Rules {
+			appConfig.Rules[output] = appmodel.Rule(rule)
+		}
+	}
+	if apiConfig.Alerts != nil && len(*apiConfig.Alerts) > 0 {
+		appConfig.Alerts = make(map[string]appmodel.Alert, len(*apiConfig.Alerts))
+		for name, alert := range *apiConfig.Alerts {
+			appConfig.Alerts[name] = appmodel.Alert(alert)
+		}
+	}
 	return appConfig
 }
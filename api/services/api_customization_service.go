@@ -17,8 +17,15 @@ package apiservices
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	apiserver "weather-app2/api/generated"
+	appmodel "weather-app2/app/model"
+	dbhelper "weather-app2/db/helper"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/frontend"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
 )
 
 // CustomizationAPIService is a service that implements the logic for the CustomizationAPIServicer
@@ -35,8 +42,89 @@ func NewCustomizationAPIService() apiserver.CustomizationAPIServicer {
 // GetDashboardTemplateByName - Get a full dashboard template
 func (s *CustomizationAPIService) GetDashboardTemplateByName(ctx context.Context, dashboardTemplateName string, projectId string) (apiserver.ImplResponse, error) {
 	if dashboardTemplateName == "Weather" {
-		return apiserver.ImplResponse{Code: http.StatusNotImplemented}, nil
+		dashboard, err := weatherDashboard(ctx, projectId)
+		if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		}
+		return apiserver.Response(http.StatusOK, dashboard), nil
 	} else {
 		return apiserver.ImplResponse{Code: http.StatusNotFound}, nil
 	}
 }
+
+// weatherWidgetTypes are the widget type definitions, in the order their widgets appear for each location.
+var weatherWidgetTypes = []string{
+	"resources/widget-types/weather-current.json",
+	"resources/widget-types/weather-forecast.json",
+	"resources/widget-types/weather-wind-rose.json",
+}
+
+func weatherDashboard(ctx context.Context, projectId string) (apiserver.Dashboard, error) {
+	widgetTypes := make([]api.WidgetType, 0, len(weatherWidgetTypes))
+	for _, path := range weatherWidgetTypes {
+		widgetType, err := common.UnmarshalFile[api.WidgetType](path)
+		if err != nil {
+			return apiserver.Dashboard{}, fmt.Errorf("reading widget type %s: %v", path, err)
+		}
+		widgetTypes = append(widgetTypes, widgetType)
+	}
+
+	assets, err := dbhelper.GetAssets(ctx)
+	if err != nil {
+		return apiserver.Dashboard{}, fmt.Errorf("getting assets: %v", err)
+	}
+
+	dashboard := apiserver.Dashboard{
+		Name:      "Weather",
+		ProjectId: projectId,
+		Widgets:   &[]apiserver.Widget{},
+	}
+	if env := frontend.GetEnvironment(ctx); env != nil {
+		dashboard.UserId = env.UserId
+	}
+
+	sequence := int32(0)
+	for _, asset := range assets {
+		if asset.ProjectID != projectId {
+			continue
+		}
+		for _, widgetType := range widgetTypes {
+			*dashboard.Widgets = append(*dashboard.Widgets, weatherWidget(asset, widgetType, sequence))
+			sequence++
+		}
+	}
+	return dashboard, nil
+}
+
+// weatherWidget binds each element of the widget type to the attribute named in its config.
+func weatherWidget(asset appmodel.Asset, widgetType api.WidgetType, sequence int32) apiserver.Widget {
+	data := make([]apiserver.WidgetData, 0, len(widgetType.Elements))
+	for i, element := range widgetType.Elements {
+		elementSequence := int32(i)
+		if element.Sequence.IsSet() && element.Sequence.Get() != nil {
+			elementSequence = *element.Sequence.Get()
+		}
+		data = append(data, apiserver.WidgetData{
+			ElementSequence: common.Ptr(elementSequence),
+			AssetId:         common.Ptr(asset.AssetID),
+			Data: &map[string]interface{}{
+				"aggregatedDataType": "heap",
+				"attribute":          element.Config["attribute"],
+				"description":        asset.LocationName,
+				"key":                "",
+				"seq":                0,
+				"subtype":            element.Config["subtype"],
+			},
+		})
+	}
+	return apiserver.Widget{
+		WidgetTypeName: widgetType.Name,
+		AssetId:        common.Ptr(asset.AssetID),
+		Sequence:       common.Ptr(sequence),
+		Details: &map[string]interface{}{
+			"size":     1,
+			"timespan": 7,
+		},
+		Data: &data,
+	}
+}
@@ -0,0 +1,250 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	apiserver "weather-app2/api/generated"
+	appmodel "weather-app2/app/model"
+	"weather-app2/broker"
+	dbhelper "weather-app2/db/helper"
+	"weather-app2/eliona"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/google/uuid"
+)
+
+// LocationsAPIService is a service that implements the logic for the LocationsAPIServicer
+// This service should implement the business logic for every endpoint for the LocationsAPI API.
+// Include any external packages or services that will be required by this service.
+type LocationsAPIService struct {
+}
+
+// NewLocationsAPIService creates a default api service
+func NewLocationsAPIService() apiserver.LocationsAPIServicer {
+	return &LocationsAPIService{}
+}
+
+// GetLocations - Get locations
+func (s *LocationsAPIService) GetLocations(ctx context.Context, projectId string) (apiserver.ImplResponse, error) {
+	assets, err := dbhelper.GetAssets(ctx)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+
+	observations, err := eliona.GetAssetTypeData(eliona.WeatherAssetType, api.SUBTYPE_INPUT)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	observationsByAsset := make(map[int32]*api.Data)
+	for i := range observations {
+		observationsByAsset[observations[i].AssetId] = &observations[i]
+	}
+
+	locations := []apiserver.Location{}
+	for _, a := range assets {
+		if projectId != "" && a.ProjectID != projectId {
+			continue
+		}
+		locations = append(locations, toAPILocation(a, observationsByAsset[a.AssetID]))
+	}
+	return apiserver.Response(http.StatusOK, locations), nil
+}
+
+// PostLocation - Creates a location
+func (s *LocationsAPIService) PostLocation(ctx context.Context, location apiserver.Location) (apiserver.ImplResponse, error) {
+	config, err := dbhelper.GetConfig(ctx)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("the app is not configured yet")
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+
+	if fieldErrors := validateCoordinates(location); len(fieldErrors) > 0 {
+		return apiserver.Response(http.StatusBadRequest, apiserver.ValidationErrors{Errors: fieldErrors}), nil
+	}
+	resolved, err := resolveLocation(config, location)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+
+	weather := eliona.Weather{
+		Identifier:   uuid.NewString(),
+		LocationName: resolved.LocationName,
+		Lat:          resolved.Lat,
+		Lon:          resolved.Lon,
+	}
+	if location.AssetName != nil {
+		weather.Name = *location.AssetName
+	}
+
+	// Create the asset only in the location's project, not in all configured ones.
+	projectConfig := config
+	projectConfig.ProjectIDs = []string{location.ProjectId}
+	if err := eliona.CreateAssets(projectConfig, []asset.AssetWithParentReferences{&weather}); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, fmt.Errorf("creating weather asset: %v", err)
+	}
+	if err := eliona.UpsertData(weather.AssetID, map[string]any{"name": weather.LocationName}, time.Now(), api.SUBTYPE_PROPERTY); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, fmt.Errorf("setting location name of asset %v: %v", weather.AssetID, err)
+	}
+
+	created, err := dbhelper.GetAssetById(weather.AssetID)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusCreated, toAPILocation(created, nil)), nil
+}
+
+// GetLocationById - Get location
+func (s *LocationsAPIService) GetLocationById(ctx context.Context, locationId int64) (apiserver.ImplResponse, error) {
+	location, err := dbhelper.GetAsset(ctx, locationId)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, fmt.Errorf("location %v not found", locationId)
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+
+	observation, err := eliona.GetAssetData(location.AssetID, api.SUBTYPE_INPUT)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, toAPILocation(location, observation)), nil
+}
+
+// PutLocationById - Updates a location
+func (s *LocationsAPIService) PutLocationById(ctx context.Context, locationId int64, location apiserver.Location) (apiserver.ImplResponse, error) {
+	existing, err := dbhelper.GetAsset(ctx, locationId)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, fmt.Errorf("location %v not found", locationId)
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+
+	config, err := dbhelper.GetConfig(ctx)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("the app is not configured yet")
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+
+	if fieldErrors := validateCoordinates(location); len(fieldErrors) > 0 {
+		return apiserver.Response(http.StatusBadRequest, apiserver.ValidationErrors{Errors: fieldErrors}), nil
+	}
+	resolved, err := resolveLocation(config, location)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+	resolved.ID = existing.ID
+
+	if err := eliona.UpsertData(existing.AssetID, map[string]any{"name": resolved.LocationName}, time.Now(), api.SUBTYPE_PROPERTY); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, fmt.Errorf("setting location name of asset %v: %v", existing.AssetID, err)
+	}
+	if err := dbhelper.UpdateAssetLocation(ctx, resolved); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+
+	updated, err := dbhelper.GetAsset(ctx, locationId)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, toAPILocation(updated, nil)), nil
+}
+
+// DeleteLocationById - Deletes a location
+func (s *LocationsAPIService) DeleteLocationById(ctx context.Context, locationId int64) (apiserver.ImplResponse, error) {
+	location, err := dbhelper.GetAsset(ctx, locationId)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, fmt.Errorf("location %v not found", locationId)
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+
+	// Delete the asset first, otherwise the app would pick it up again as a new location.
+	if err := eliona.DeleteAsset(location.AssetID); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, fmt.Errorf("deleting weather asset %v: %v", location.AssetID, err)
+	}
+	if err := dbhelper.DeleteAsset(ctx, locationId); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusNoContent, nil), nil
+}
+
+// validateCoordinates checks that the coordinates of the location, if set, are on the globe.
+func validateCoordinates(location apiserver.Location) []apiserver.FieldError {
+	var fieldErrors []apiserver.FieldError
+	if location.Lat != nil && (*location.Lat < -90 || *location.Lat > 90) {
+		fieldErrors = append(fieldErrors, apiserver.FieldError{Field: "lat", Message: "must be between -90 and 90"})
+	}
+	if location.Lon != nil && (*location.Lon < -180 || *location.Lon > 180) {
+		fieldErrors = append(fieldErrors, apiserver.FieldError{Field: "lon", Message: "must be between -180 and 180"})
+	}
+	return fieldErrors
+}
+
+// resolveLocation takes the coordinates if both are set, otherwise it locates the query at the provider.
+func resolveLocation(config appmodel.Configuration, location apiserver.Location) (appmodel.Asset, error) {
+	if location.Lat != nil && location.Lon != nil {
+		name := fmt.Sprintf("%f, %f", *location.Lat, *location.Lon)
+		if location.Query != nil && *location.Query != "" {
+			name = *location.Query
+		}
+		return appmodel.Asset{
+			ProjectID:    location.ProjectId,
+			LocationName: name,
+			Lat:          *location.Lat,
+			Lon:          *location.Lon,
+		}, nil
+	}
+	if location.Query == nil || *location.Query == "" {
+		return appmodel.Asset{}, fmt.Errorf("either query or both lat and lon must be set")
+	}
+
+	geolocation, err := broker.Locate(config, *location.Query)
+	if err != nil {
+		return appmodel.Asset{}, fmt.Errorf("locating %s: %v", *location.Query, err)
+	}
+	return appmodel.Asset{
+		ProjectID:    location.ProjectId,
+		LocationName: geolocation.FormattedName(),
+		Lat:          geolocation.Lat,
+		Lon:          geolocation.Lon,
+	}, nil
+}
+
+func toAPILocation(location appmodel.Asset, observation *api.Data) apiserver.Location {
+	apiLocation := apiserver.Location{
+		Id:           &location.ID,
+		ProjectId:    location.ProjectID,
+		AssetId:      &location.AssetID,
+		LocationName: &location.LocationName,
+		Lat:          &location.Lat,
+		Lon:          &location.Lon,
+	}
+	if observation != nil {
+		apiLocation.LastObservation = &apiserver.Observation{
+			Data: observation.Data,
+		}
+		if timestamp := observation.Timestamp.Get(); timestamp != nil {
+			apiLocation.LastObservation.Timestamp = *timestamp
+		}
+	}
+	return apiLocation
+}
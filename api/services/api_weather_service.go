@@ -0,0 +1,226 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	apiserver "weather-app2/api/generated"
+	"weather-app2/broker"
+	dbhelper "weather-app2/db/helper"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+// weatherCacheTTL defines how long a weather report is served from the cache.
+const weatherCacheTTL = 5 * time.Minute
+
+type cachedWeatherReport struct {
+	report  apiserver.WeatherReport
+	expires time.Time
+}
+
+// WeatherAPIService is a service that implements the logic for the WeatherAPIServicer
+// This service should implement the business logic for every endpoint for the WeatherAPI API.
+// Include any external packages or services that will be required by this service.
+type WeatherAPIService struct {
+	cacheMutex sync.Mutex
+	cache      map[string]cachedWeatherReport
+}
+
+// NewWeatherAPIService creates a default api service
+func NewWeatherAPIService() apiserver.WeatherAPIServicer {
+	return &WeatherAPIService{
+		cache: make(map[string]cachedWeatherReport),
+	}
+}
+
+// GetWeather - Get weather for a location
+func (s *WeatherAPIService) GetWeather(ctx context.Context, latParam *float64, lonParam *float64, q string, forecast bool) (apiserver.ImplResponse, error) {
+	q = strings.TrimSpace(q)
+	if (latParam == nil) != (lonParam == nil) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("both lat and lon must be set")
+	}
+	useCoordinates := latParam != nil
+	if !useCoordinates && q == "" {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("either q or lat and lon must be set")
+	}
+	var lat, lon float64
+	if useCoordinates {
+		lat, lon = *latParam, *lonParam
+		if lat < -90 || lat > 90 {
+			return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("lat %v is out of range", lat)
+		}
+		if lon < -180 || lon > 180 {
+			return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("lon %v is out of range", lon)
+		}
+	}
+
+	key := fmt.Sprintf("%.4f,%.4f,%t", lat, lon, forecast)
+	if !useCoordinates {
+		key = fmt.Sprintf("%s,%t", strings.ToLower(q), forecast)
+	}
+	if report, ok := s.cached(key); ok {
+		return apiserver.Response(http.StatusOK, report), nil
+	}
+
+	config, err := dbhelper.GetConfig(ctx)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("the app is not configured yet")
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+
+	var report apiserver.WeatherReport
+	if !useCoordinates {
+		location, err := broker.Locate(config, q)
+		if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("locating %s: %v", q, err)
+		}
+		report.LocationName = common.Ptr(location.FormattedName())
+		lat, lon = location.Lat, location.Lon
+	}
+	report.Lat, report.Lon = lat, lon
+
+	getWeather := broker.GetWeather
+	if forecast {
+		getWeather = broker.GetWeatherWithForecast
+	}
+	weather, err := getWeather(lat, lon, config.ApiKey)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadGateway}, fmt.Errorf("getting weather: %v", err)
+	}
+
+	report.Current = toAPICurrentWeather(weather.Current)
+	if forecast {
+		hourly := make([]apiserver.WeatherConditions, 0, len(weather.Hourly))
+		for _, h := range weather.Hourly {
+			hourly = append(hourly, toAPIHourlyWeather(h))
+		}
+		daily := make([]apiserver.DailyForecast, 0, len(weather.Daily))
+		for _, d := range weather.Daily {
+			daily = append(daily, toAPIDailyForecast(d))
+		}
+		report.Hourly = &hourly
+		report.Daily = &daily
+	}
+
+	s.store(key, report)
+	return apiserver.Response(http.StatusOK, report), nil
+}
+
+func (s *WeatherAPIService) cached(key string) (apiserver.WeatherReport, bool) {
+	s.cacheMutex.Lock()
+	defer s.cacheMutex.Unlock()
+
+	entry, ok := s.cache[key]
+	if !ok || time.Now().After(entry.expires) {
+		return apiserver.WeatherReport{}, false
+	}
+	return entry.report, true
+}
+
+func (s *WeatherAPIService) store(key string, report apiserver.WeatherReport) {
+	s.cacheMutex.Lock()
+	defer s.cacheMutex.Unlock()
+
+	now := time.Now()
+	for k, entry := range s.cache {
+		if now.After(entry.expires) {
+			delete(s.cache, k)
+		}
+	}
+	s.cache[key] = cachedWeatherReport{report: report, expires: now.Add(weatherCacheTTL)}
+}
+
+func toAPICurrentWeather(c broker.CurrentWeather) apiserver.WeatherConditions {
+	conditions := apiserver.WeatherConditions{
+		Time:        time.Unix(c.Dt, 0).UTC(),
+		Temperature: c.Temp,
+		FeelsLike:   c.FeelsLike,
+		Pressure:    int32(c.Pressure),
+		Humidity:    int32(c.Humidity),
+		DewPoint:    c.DewPoint,
+		Uvi:         c.Uvi,
+		Clouds:      int32(c.Clouds),
+		Visibility:  int32(c.Visibility),
+		WindSpeed:   c.WindSpeed,
+		WindDeg:     int32(c.WindDeg),
+		WindGust:    c.WindGust,
+		Rain:        c.Rain.OneHour,
+		Snow:        c.Snow.OneHour,
+	}
+	if len(c.Weather) > 0 {
+		conditions.Condition = c.Weather[0].Main
+		conditions.Description = c.Weather[0].Description
+	}
+	return conditions
+}
+
+func toAPIHourlyWeather(h broker.HourlyWeather) apiserver.WeatherConditions {
+	conditions := apiserver.WeatherConditions{
+		Time:                     time.Unix(h.Dt, 0).UTC(),
+		Temperature:              h.Temp,
+		FeelsLike:                h.FeelsLike,
+		Pressure:                 int32(h.Pressure),
+		Humidity:                 int32(h.Humidity),
+		DewPoint:                 h.DewPoint,
+		Uvi:                      h.Uvi,
+		Clouds:                   int32(h.Clouds),
+		Visibility:               int32(h.Visibility),
+		WindSpeed:                h.WindSpeed,
+		WindDeg:                  int32(h.WindDeg),
+		WindGust:                 h.WindGust,
+		PrecipitationProbability: common.Ptr(h.Pop),
+		Rain:                     h.Rain.OneHour,
+		Snow:                     h.Snow.OneHour,
+	}
+	if len(h.Weather) > 0 {
+		conditions.Condition = h.Weather[0].Main
+		conditions.Description = h.Weather[0].Description
+	}
+	return conditions
+}
+
+func toAPIDailyForecast(d broker.DailyWeather) apiserver.DailyForecast {
+	forecast := apiserver.DailyForecast{
+		Time:                     time.Unix(d.Dt, 0).UTC(),
+		Sunrise:                  time.Unix(d.Sunrise, 0).UTC(),
+		Sunset:                   time.Unix(d.Sunset, 0).UTC(),
+		TemperatureMin:           d.Temp.Min,
+		TemperatureMax:           d.Temp.Max,
+		TemperatureDay:           d.Temp.Day,
+		Pressure:                 int32(d.Pressure),
+		Humidity:                 int32(d.Humidity),
+		WindSpeed:                d.WindSpeed,
+		WindDeg:                  int32(d.WindDeg),
+		Clouds:                   int32(d.Clouds),
+		Uvi:                      d.Uvi,
+		PrecipitationProbability: d.Pop,
+		Rain:                     d.Rain,
+		Snow:                     d.Snow,
+	}
+	if len(d.Weather) > 0 {
+		forecast.Condition = d.Weather[0].Main
+		forecast.Description = d.Weather[0].Description
+	}
+	return forecast
+}
@@ -0,0 +1,237 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	apiserver "weather-app2/api/generated"
+	appmodel "weather-app2/app/model"
+	dbhelper "weather-app2/db/helper"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// defaultExportRange is exported if the request does not give a start.
+const defaultExportRange = 7 * 24 * time.Hour
+
+// exportContentTypes lists the supported export formats.
+var exportContentTypes = map[string]string{
+	"csv":    "text/csv; charset=utf-8",
+	"ndjson": "application/x-ndjson",
+}
+
+type exportRequest struct {
+	locations  []appmodel.Asset
+	from       time.Time
+	to         time.Time
+	attributes []string
+	imperial   bool
+	timezone   *time.Location
+}
+
+// ExportWeatherHistory - Export weather history
+func (s *LocationsAPIService) ExportWeatherHistory(ctx context.Context, locations []int64, from time.Time, to time.Time, attributes []string, format string, units string, timezone string) (apiserver.ImplResponse, error) {
+	if _, ok := exportContentTypes[format]; !ok {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("unknown format %q", format)
+	}
+	request := exportRequest{from: from, to: to, attributes: attributes}
+	switch units {
+	case "metric":
+	case "imperial":
+		request.imperial = true
+	default:
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("unknown unit system %q", units)
+	}
+	var err error
+	if request.timezone, err = time.LoadLocation(timezone); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("unknown timezone %q", timezone)
+	}
+	if request.to.IsZero() {
+		request.to = time.Now()
+	}
+	if request.from.IsZero() {
+		request.from = request.to.Add(-defaultExportRange)
+	}
+	if !request.from.Before(request.to) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, errors.New("from must be before to")
+	}
+
+	var ids []int64
+	for _, id := range locations {
+		if slices.Contains(ids, id) {
+			continue
+		}
+		ids = append(ids, id)
+		location, err := dbhelper.GetAsset(ctx, id)
+		if errors.Is(err, dbhelper.ErrNotFound) {
+			return apiserver.ImplResponse{Code: http.StatusNotFound}, fmt.Errorf("location %v not found", id)
+		} else if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+		}
+		request.locations = append(request.locations, location)
+	}
+	if len(request.attributes) == 0 {
+		request.attributes, err = dbhelper.GetObservationAttributes(ctx, ids, request.from, request.to)
+		if err != nil {
+			return apiserver.ImplResponse{Code: http.StatusInternalServerError}, fmt.Errorf("getting exported attributes: %v", err)
+		}
+	}
+
+	return apiserver.Response(http.StatusOK, exportStream(ctx, format, request)), nil
+}
+
+// exportStream streams the export to the response. Each location is written and flushed before the next
+// one is read from the database, so only one location at a time is held in memory.
+func exportStream(ctx context.Context, format string, request exportRequest) apiserver.StreamResponse {
+	write := writeNDJSON
+	if format == "csv" {
+		write = writeCSV
+	}
+	return apiserver.StreamResponse{
+		ContentType: exportContentTypes[format],
+		Filename:    "weather-export." + format,
+		Write: func(w io.Writer, flush func()) error {
+			err := write(ctx, w, flush, request)
+			if err != nil {
+				// The status is already sent, the client only sees a truncated file.
+				log.Error("services", "exporting weather history: %v", err)
+			}
+			return err
+		},
+	}
+}
+
+func writeCSV(ctx context.Context, w io.Writer, flushResponse func(), request exportRequest) error {
+	writer := csv.NewWriter(w)
+	header := append([]string{"location_id", "location_name", "latitude", "longitude", "timestamp"}, request.attributes...)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	err := exportObservations(ctx, request, func(location appmodel.Asset, o appmodel.Observation) error {
+		row := []string{
+			strconv.FormatInt(location.ID, 10),
+			location.LocationName,
+			strconv.FormatFloat(location.Lat, 'f', -1, 64),
+			strconv.FormatFloat(location.Lon, 'f', -1, 64),
+			o.ObservedAt.In(request.timezone).Format(time.RFC3339),
+		}
+		for _, attribute := range request.attributes {
+			value, ok := o.Data[attribute]
+			if !ok {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, strconv.FormatFloat(exportValue(request, attribute, value), 'f', -1, 64))
+		}
+		return writer.Write(row)
+	}, func() error {
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+		flushResponse()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// Without any observations only the header was written.
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeNDJSON(ctx context.Context, w io.Writer, flushResponse func(), request exportRequest) error {
+	encoder := json.NewEncoder(w)
+	return exportObservations(ctx, request, func(location appmodel.Asset, o appmodel.Observation) error {
+		record := apiserver.ExportRecord{
+			LocationId:   location.ID,
+			LocationName: location.LocationName,
+			Latitude:     location.Lat,
+			Longitude:    location.Lon,
+			Timestamp:    o.ObservedAt.In(request.timezone),
+			Values:       make(map[string]float64, len(request.attributes)),
+		}
+		for _, attribute := range request.attributes {
+			if value, ok := o.Data[attribute]; ok {
+				record.Values[attribute] = exportValue(request, attribute, value)
+			}
+		}
+		return encoder.Encode(record)
+	}, func() error {
+		flushResponse()
+		return nil
+	})
+}
+
+// exportObservations reads the observations location by location and writes each one, flushing after
+// every location.
+func exportObservations(ctx context.Context, request exportRequest, write func(appmodel.Asset, appmodel.Observation) error, flush func() error) error {
+	for _, location := range request.locations {
+		observations, err := dbhelper.GetObservations(ctx, location.ID, request.from, request.to)
+		if err != nil {
+			return err
+		}
+		for _, o := range observations {
+			if err := write(location, o); err != nil {
+				return fmt.Errorf("writing location %v: %v", location.ID, err)
+			}
+		}
+		if err := flush(); err != nil {
+			return fmt.Errorf("writing location %v: %v", location.ID, err)
+		}
+	}
+	return nil
+}
+
+// exportValue converts a stored metric value to the requested unit system. Imperial units follow the
+// provider's: temperatures in °F and wind speeds in mph. Degree days are converted to °F·d, everything
+// else is unchanged.
+func exportValue(request exportRequest, attribute string, value float64) float64 {
+	if !request.imperial {
+		return value
+	}
+	switch {
+	case temperatureAttributes[attribute], strings.HasPrefix(attribute, "forecast_temperature_"):
+		return value*9/5 + 32
+	case strings.Contains(attribute, "_degree_days_"):
+		return value * 9 / 5
+	case attribute == "wind_speed", attribute == "wind_gust":
+		return value / 0.44704
+	}
+	return value
+}
+
+// temperatureAttributes are the attributes in °C, besides the temperature forecasts.
+var temperatureAttributes = map[string]bool{
+	"temperature":          true,
+	"feels_like":           true,
+	"dew_point":            true,
+	"wet_bulb_temperature": true,
+	"heat_index":           true,
+	"wind_chill":           true,
+	"humidex":              true,
+	"utci":                 true,
+}
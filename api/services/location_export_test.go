@@ -0,0 +1,73 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	apiserver "weather-app2/api/generated"
+)
+
+func TestExportStreamHeaders(t *testing.T) {
+	request := exportRequest{
+		from:       time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		to:         time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		attributes: []string{"temperature", "humidity"},
+		timezone:   time.UTC,
+	}
+	tests := []struct {
+		format             string
+		contentType        string
+		contentDisposition string
+		body               string
+	}{
+		{
+			format:             "csv",
+			contentType:        "text/csv; charset=utf-8",
+			contentDisposition: "attachment; filename=weather-export.csv",
+			body:               "location_id,location_name,latitude,longitude,timestamp,temperature,humidity\n",
+		},
+		{
+			format:             "ndjson",
+			contentType:        "application/x-ndjson",
+			contentDisposition: "attachment; filename=weather-export.ndjson",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			code := http.StatusOK
+			if err := apiserver.EncodeJSONResponse(exportStream(context.Background(), tt.format, request), &code, recorder); err != nil {
+				t.Fatal(err)
+			}
+			if recorder.Code != http.StatusOK {
+				t.Errorf("status %d, want %d", recorder.Code, http.StatusOK)
+			}
+			if got := recorder.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Content-Type %q, want %q", got, tt.contentType)
+			}
+			if got := recorder.Header().Get("Content-Disposition"); got != tt.contentDisposition {
+				t.Errorf("Content-Disposition %q, want %q", got, tt.contentDisposition)
+			}
+			if got := recorder.Body.String(); got != tt.body {
+				t.Errorf("body %q, want %q", got, tt.body)
+			}
+		})
+	}
+}
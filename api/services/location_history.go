@@ -0,0 +1,159 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+	apiserver "weather-app2/api/generated"
+	appmodel "weather-app2/app/model"
+	dbhelper "weather-app2/db/helper"
+)
+
+// defaultHistoryRange is the time range returned if no start is given.
+const defaultHistoryRange = 24 * time.Hour
+
+// GetLocationHistory - Get weather history of a location
+func (s *LocationsAPIService) GetLocationHistory(ctx context.Context, locationId int64, from time.Time, to time.Time, attributes []string, resolution string, aggregation string) (apiserver.ImplResponse, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultHistoryRange)
+	}
+	if !from.Before(to) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, errors.New("from must be before to")
+	}
+	bucket, ok := historyBuckets[resolution]
+	if !ok {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("unknown resolution %q", resolution)
+	}
+	aggregate, ok := historyAggregations[aggregation]
+	if !ok {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("unknown aggregation %q", aggregation)
+	}
+
+	location, err := dbhelper.GetAsset(ctx, locationId)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, fmt.Errorf("location %v not found", locationId)
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	observations, err := dbhelper.GetObservations(ctx, location.ID, from, to)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+
+	selectAttributes(observations, attributes)
+	history := apiserver.LocationHistory{
+		LocationId:  location.ID,
+		Resolution:  resolution,
+		Aggregation: aggregation,
+	}
+	if bucket == nil {
+		history.Aggregation = ""
+		history.Points = make([]apiserver.HistoryPoint, 0, len(observations))
+		for _, o := range observations {
+			history.Points = append(history.Points, apiserver.HistoryPoint{Timestamp: o.ObservedAt, Values: o.Data})
+		}
+	} else {
+		history.Points = resample(observations, bucket, aggregate)
+	}
+	return apiserver.Response(http.StatusOK, history), nil
+}
+
+// historyBuckets return the start of the bucket an observation falls into. Days are UTC days.
+// Raw history is not resampled.
+var historyBuckets = map[string]func(time.Time) time.Time{
+	"raw":    nil,
+	"hourly": func(t time.Time) time.Time { return t.UTC().Truncate(time.Hour) },
+	"daily":  func(t time.Time) time.Time { return t.UTC().Truncate(24 * time.Hour) },
+}
+
+var historyAggregations = map[string]func([]float64) float64{
+	"mean": func(values []float64) float64 {
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values))
+	},
+	"min": func(values []float64) float64 {
+		result := math.Inf(1)
+		for _, v := range values {
+			result = math.Min(result, v)
+		}
+		return result
+	},
+	"max": func(values []float64) float64 {
+		result := math.Inf(-1)
+		for _, v := range values {
+			result = math.Max(result, v)
+		}
+		return result
+	},
+}
+
+// selectAttributes removes all but the given attributes from the observations. No attributes keeps all.
+func selectAttributes(observations []appmodel.Observation, attributes []string) {
+	if len(attributes) == 0 {
+		return
+	}
+	keep := make(map[string]bool, len(attributes))
+	for _, attribute := range attributes {
+		keep[attribute] = true
+	}
+	for _, o := range observations {
+		for attribute := range o.Data {
+			if !keep[attribute] {
+				delete(o.Data, attribute)
+			}
+		}
+	}
+}
+
+// resample aggregates the values of each attribute per bucket. The observations must be sorted by time.
+func resample(observations []appmodel.Observation, bucket func(time.Time) time.Time, aggregate func([]float64) float64) []apiserver.HistoryPoint {
+	points := make([]apiserver.HistoryPoint, 0)
+	var start time.Time
+	values := make(map[string][]float64)
+	flush := func() {
+		if len(values) == 0 {
+			return
+		}
+		point := apiserver.HistoryPoint{Timestamp: start, Values: make(map[string]float64, len(values))}
+		for attribute, v := range values {
+			point.Values[attribute] = aggregate(v)
+		}
+		points = append(points, point)
+		values = make(map[string][]float64)
+	}
+	for _, o := range observations {
+		if b := bucket(o.ObservedAt); !b.Equal(start) {
+			flush()
+			start = b
+		}
+		for attribute, v := range o.Data {
+			values[attribute] = append(values[attribute], v)
+		}
+	}
+	flush()
+	return points
+}
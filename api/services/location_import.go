@@ -0,0 +1,329 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	apiserver "weather-app2/api/generated"
+	appmodel "weather-app2/app/model"
+	"weather-app2/broker"
+	dbhelper "weather-app2/db/helper"
+	"weather-app2/eliona"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/google/uuid"
+)
+
+const (
+	importStatusCreated   = "created"
+	importStatusValid     = "valid"
+	importStatusAmbiguous = "ambiguous"
+	importStatusExists    = "exists"
+	importStatusError     = "error"
+)
+
+// locationImportRow is one row of a location import file.
+type locationImportRow struct {
+	ProjectID string   `json:"projectId"`
+	AssetName string   `json:"assetName"`
+	Address   string   `json:"address"`
+	Lat       *float64 `json:"lat"`
+	Lon       *float64 `json:"lon"`
+	ParentGAI string   `json:"parentGAI"`
+
+	err error
+}
+
+// ImportLocations - Imports locations from a file
+func (s *LocationsAPIService) ImportLocations(ctx context.Context, dryRun bool, file *os.File) (apiserver.ImplResponse, error) {
+	defer os.Remove(file.Name())
+	content, err := os.ReadFile(file.Name())
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, fmt.Errorf("reading uploaded file: %v", err)
+	}
+	rows, err := parseLocationImport(content)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, err
+	}
+
+	config, err := dbhelper.GetConfig(ctx)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusBadRequest}, fmt.Errorf("the app is not configured yet")
+	} else if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+
+	assets, err := dbhelper.GetAssets(ctx)
+	if err != nil && !errors.Is(err, dbhelper.ErrNotFound) {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, fmt.Errorf("getting locations: %v", err)
+	}
+	existing := make(map[string]appmodel.Asset, len(assets))
+	for _, asset := range assets {
+		existing[importKey(asset.ProjectID, asset.LocationName)] = asset
+	}
+	importedRows := make(map[string]int)
+
+	results := make([]apiserver.LocationImportResult, len(rows))
+	weathersByProject := make(map[string][]*eliona.Weather)
+	resultsByWeather := make(map[*eliona.Weather]*apiserver.LocationImportResult)
+	for i, row := range rows {
+		result := &results[i]
+		result.Row = int32(i + 1)
+
+		if row.err == nil {
+			row.err = row.validate()
+		}
+		if row.err == nil && !slices.Contains(config.ProjectIDs, row.ProjectID) {
+			row.err = fmt.Errorf("project %s is not configured for the app", row.ProjectID)
+		}
+		if row.err != nil {
+			result.Status = importStatusError
+			result.Message = common.Ptr(row.err.Error())
+			continue
+		}
+
+		weather := &eliona.Weather{
+			Name:                row.AssetName,
+			LocationalParentGAI: row.ParentGAI,
+		}
+		if row.Lat != nil && row.Lon != nil {
+			weather.LocationName = row.Address
+			if weather.LocationName == "" {
+				weather.LocationName = fmt.Sprintf("%f, %f", *row.Lat, *row.Lon)
+			}
+			weather.Lat, weather.Lon = *row.Lat, *row.Lon
+		} else {
+			candidates, err := broker.Search(config, row.Address)
+			if err != nil {
+				result.Status = importStatusError
+				result.Message = common.Ptr(fmt.Sprintf("locating %s: %v", row.Address, err))
+				continue
+			}
+			if names := distinctNames(candidates); len(names) > 1 {
+				result.Status = importStatusAmbiguous
+				result.Message = common.Ptr("address matches more than one location, please be more specific or use coordinates")
+				result.Candidates = &names
+				continue
+			}
+			weather.LocationName = candidates[0].FormattedName()
+			weather.Lat, weather.Lon = candidates[0].Lat, candidates[0].Lon
+		}
+
+		key := importKey(row.ProjectID, weather.LocationName)
+		if asset, ok := existing[key]; ok {
+			result.Status = importStatusExists
+			result.Message = common.Ptr("location already exists in the project")
+			result.Location = common.Ptr(toAPILocation(asset, nil))
+			continue
+		}
+		if firstRow, ok := importedRows[key]; ok {
+			result.Status = importStatusExists
+			result.Message = common.Ptr(fmt.Sprintf("location is already imported by row %d", firstRow))
+			continue
+		}
+		importedRows[key] = i + 1
+		// Derive the GAI from the location, so that the asset isn't duplicated in Eliona even if an
+		// earlier import created it there without recording the location.
+		weather.Identifier = uuid.NewSHA1(uuid.NameSpaceOID, []byte(key)).String()
+
+		result.Location = &apiserver.Location{
+			ProjectId:    row.ProjectID,
+			AssetName:    common.Ptr(weather.GetName()),
+			LocationName: common.Ptr(weather.LocationName),
+			Lat:          common.Ptr(weather.Lat),
+			Lon:          common.Ptr(weather.Lon),
+		}
+		if dryRun {
+			result.Status = importStatusValid
+			continue
+		}
+		weathersByProject[row.ProjectID] = append(weathersByProject[row.ProjectID], weather)
+		resultsByWeather[weather] = result
+	}
+
+	projectIDs := make([]string, 0, len(weathersByProject))
+	for projectID := range weathersByProject {
+		projectIDs = append(projectIDs, projectID)
+	}
+	sort.Strings(projectIDs)
+
+	for _, projectID := range projectIDs {
+		weathers := weathersByProject[projectID]
+		err := createWeatherAssets(config, projectID, weathers)
+		for _, weather := range weathers {
+			result := resultsByWeather[weather]
+			if err != nil {
+				result.Status = importStatusError
+				result.Message = common.Ptr(fmt.Sprintf("creating assets in project %s: %v", projectID, err))
+				continue
+			}
+			result.Status = importStatusCreated
+			result.Location.AssetId = common.Ptr(weather.AssetID)
+		}
+	}
+
+	return apiserver.Response(http.StatusOK, results), nil
+}
+
+func createWeatherAssets(config appmodel.Configuration, projectID string, weathers []*eliona.Weather) error {
+	assets := make([]asset.AssetWithParentReferences, 0, len(weathers))
+	for _, weather := range weathers {
+		assets = append(assets, weather)
+	}
+
+	// Create the assets only in the rows' project, not in all configured ones.
+	config.ProjectIDs = []string{projectID}
+	if err := eliona.CreateAssets(config, assets); err != nil {
+		return err
+	}
+
+	names := make(map[int32]map[string]any, len(weathers))
+	for _, weather := range weathers {
+		names[weather.AssetID] = map[string]any{"name": weather.LocationName}
+	}
+	if err := eliona.UpsertDataBulk(names, time.Now(), api.SUBTYPE_PROPERTY); err != nil {
+		// The assets and locations exist at this point, only the displayed name is missing.
+		log.Error("eliona", "setting location names of imported assets: %v", err)
+	}
+	return nil
+}
+
+func (row locationImportRow) validate() error {
+	if row.ProjectID == "" {
+		return fmt.Errorf("projectId is missing")
+	}
+	if (row.Lat == nil) != (row.Lon == nil) {
+		return fmt.Errorf("both lat and lon must be set")
+	}
+	if row.Lat == nil && row.Address == "" {
+		return fmt.Errorf("either address or lat and lon must be set")
+	}
+	if row.Lat != nil && (*row.Lat < -90 || *row.Lat > 90) {
+		return fmt.Errorf("lat %v is out of range", *row.Lat)
+	}
+	if row.Lon != nil && (*row.Lon < -180 || *row.Lon > 180) {
+		return fmt.Errorf("lon %v is out of range", *row.Lon)
+	}
+	return nil
+}
+
+// importKey identifies a location within a project by its normalized name.
+func importKey(projectID string, locationName string) string {
+	return projectID + "/" + strings.ToLower(strings.Join(strings.Fields(locationName), " "))
+}
+
+func distinctNames(locations []broker.Geolocation) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, location := range locations {
+		name := location.FormattedName()
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// parseLocationImport reads the rows from a JSON array or a CSV file with header row.
+func parseLocationImport(content []byte) ([]locationImportRow, error) {
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+	if trimmed[0] == '[' {
+		var rows []locationImportRow
+		if err := json.Unmarshal(trimmed, &rows); err != nil {
+			return nil, fmt.Errorf("parsing JSON: %v", err)
+		}
+		return rows, nil
+	}
+	return parseLocationImportCSV(trimmed)
+}
+
+func parseLocationImportCSV(content []byte) ([]locationImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %v", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["projectid"]; !ok {
+		return nil, fmt.Errorf("CSV header has no projectId column")
+	}
+
+	var rows []locationImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("reading CSV: %v", err)
+		}
+		field := func(name string) string {
+			if i, ok := columns[strings.ToLower(name)]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := locationImportRow{
+			ProjectID: field("projectId"),
+			AssetName: field("assetName"),
+			Address:   field("address"),
+			ParentGAI: field("parentGAI"),
+		}
+		row.Lat, row.err = parseOptionalFloat(field("lat"))
+		if row.err == nil {
+			row.Lon, row.err = parseOptionalFloat(field("lon"))
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseOptionalFloat(value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parsing number %q: %v", value, err)
+	}
+	return &f, nil
+}
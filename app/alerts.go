@@ -0,0 +1,187 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"sync"
+	"time"
+	appmodel "weather-app2/app/model"
+	"weather-app2/eliona"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+type alertState struct {
+	rule ruleState
+	// notified is whether the users know about the current triggering, so that they get a resolve message.
+	notified     bool
+	lastNotified time.Time
+	// alert and value are the definition and the last value, for the resolve message once the alert or the
+	// location is deleted.
+	alert appmodel.Alert
+	value float64
+}
+
+type alertNotification struct {
+	name     string
+	alert    appmodel.Alert
+	value    float64
+	resolved bool
+}
+
+// locationAlerts are the notifications due for a location.
+type locationAlerts struct {
+	asset         appmodel.Asset
+	notifications []alertNotification
+}
+
+// alertTracker keeps the state of the alerts per location. After a restart, alerts whose threshold is
+// crossed notify again.
+type alertTracker struct {
+	mu     sync.Mutex
+	states map[int32]map[string]alertState
+	// assets are the locations the states belong to.
+	assets map[int32]appmodel.Asset
+}
+
+var alertStates = newAlertTracker()
+
+func newAlertTracker() *alertTracker {
+	return &alertTracker{
+		states: make(map[int32]map[string]alertState),
+		assets: make(map[int32]appmodel.Asset),
+	}
+}
+
+// evaluate returns the notifications due for the location. An alert notifies when its threshold is crossed,
+// unless it notified about the location within its cooldown; it then notifies once the cooldown has passed if
+// the threshold is still crossed. A resolve message follows once the value is back past the hysteresis, or
+// once the alert is deleted or no longer applies to the location.
+func (t *alertTracker) evaluate(asset appmodel.Asset, weatherMap map[string]any, now time.Time, alerts map[string]appmodel.Alert) []alertNotification {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.assets[asset.AssetID] = asset
+	states, ok := t.states[asset.AssetID]
+	if !ok {
+		states = make(map[string]alertState)
+		t.states[asset.AssetID] = states
+	}
+
+	var notifications []alertNotification
+	for name, state := range states {
+		if alert, ok := alerts[name]; ok && alert.AppliesTo(asset) {
+			continue
+		}
+		if state.notified {
+			value, ok := toFloat(weatherMap[state.alert.Attribute])
+			if !ok {
+				value = state.value
+			}
+			notifications = append(notifications, alertNotification{name: name, alert: state.alert, value: value, resolved: true})
+		}
+		delete(states, name)
+	}
+	for name, alert := range alerts {
+		if !alert.AppliesTo(asset) {
+			continue
+		}
+		value, ok := toFloat(weatherMap[alert.Attribute])
+		if !ok {
+			continue
+		}
+		state, known := states[name]
+		state.rule = nextRuleState(alert.Rule(), state.rule, known, value, now)
+		state.alert = alert
+		state.value = value
+		cooldown := time.Duration(alert.Cooldown) * time.Second
+		if state.rule.on && !state.notified && now.Sub(state.lastNotified) >= cooldown {
+			notifications = append(notifications, alertNotification{name: name, alert: alert, value: value})
+			state.notified = true
+			state.lastNotified = now
+		} else if !state.rule.on && state.notified {
+			notifications = append(notifications, alertNotification{name: name, alert: alert, value: value, resolved: true})
+			state.notified = false
+		}
+		states[name] = state
+	}
+	return notifications
+}
+
+// prune drops the state of locations that were deleted. It returns the resolve messages of their alerts that
+// are still triggered.
+func (t *alertTracker) prune(assets []appmodel.Asset) []locationAlerts {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	present := make(map[int32]bool, len(assets))
+	for _, asset := range assets {
+		present[asset.AssetID] = true
+	}
+	var resolved []locationAlerts
+	for assetID, states := range t.states {
+		if present[assetID] {
+			continue
+		}
+		var notifications []alertNotification
+		for name, state := range states {
+			if state.notified {
+				notifications = append(notifications, alertNotification{name: name, alert: state.alert, value: state.value, resolved: true})
+			}
+		}
+		if len(notifications) > 0 {
+			resolved = append(resolved, locationAlerts{asset: t.assets[assetID], notifications: notifications})
+		}
+		delete(t.states, assetID)
+		delete(t.assets, assetID)
+	}
+	return resolved
+}
+
+// notifyAlerts sends the notifications of a location to the users of the alerts.
+func notifyAlerts(config *appmodel.Configuration, asset appmodel.Asset, notifications []alertNotification) {
+	if len(notifications) == 0 {
+		return
+	}
+	attributes, err := eliona.GetWeatherAttributes()
+	if err != nil {
+		log.Error("eliona", "getting weather attributes for alerts: %v", err)
+		return
+	}
+	for _, notification := range notifications {
+		alert := eliona.ThresholdAlert{
+			Name:         notification.name,
+			Alert:        notification.alert,
+			LocationName: asset.LocationName,
+			Attribute:    api.AssetTypeAttribute{Name: notification.alert.Attribute},
+			Value:        notification.value,
+			Resolved:     notification.resolved,
+		}
+		for _, attribute := range attributes {
+			if attribute.Name == notification.alert.Attribute {
+				alert.Attribute = attribute
+			}
+		}
+		users := notification.alert.Users
+		if len(users) == 0 && config.UserId != "" {
+			users = []string{config.UserId}
+		}
+		for _, user := range users {
+			if err := eliona.NotifyThresholdAlert(user, asset.ProjectID, alert); err != nil {
+				log.Error("eliona", "notifying %s about alert %s at %s: %v", user, notification.name, asset.LocationName, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"testing"
+	"time"
+	appmodel "weather-app2/app/model"
+)
+
+func TestAlertCooldownAndResolve(t *testing.T) {
+	frost := appmodel.Alert{Attribute: "temperature", Condition: appmodel.RuleBelow, Threshold: -10, Hysteresis: 1, Cooldown: 3600}
+	alerts := map[string]appmodel.Alert{"frost": frost}
+	tracker := newAlertTracker()
+	asset := appmodel.Asset{ID: 1, ProjectID: "10", AssetID: 100}
+	start := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		after       time.Duration
+		temperature float64
+		want        string
+	}{
+		{after: 0, temperature: -5, want: ""},
+		{after: 10 * time.Minute, temperature: -11, want: "triggered"},
+		{after: 20 * time.Minute, temperature: -9.5, want: ""}, // Within the hysteresis.
+		{after: 30 * time.Minute, temperature: -8, want: "resolved"},
+		{after: 40 * time.Minute, temperature: -12, want: ""}, // Within the cooldown.
+		{after: 50 * time.Minute, temperature: -8, want: ""},  // Not notified, so nothing to resolve.
+		{after: 60 * time.Minute, temperature: -12, want: ""},
+		{after: 70 * time.Minute, temperature: -12, want: "triggered"}, // Still below once the cooldown passed.
+		{after: 80 * time.Minute, temperature: -5, want: "resolved"},
+	}
+	for i, step := range steps {
+		notifications := tracker.evaluate(asset, map[string]any{"temperature": step.temperature}, start.Add(step.after), alerts)
+		got := ""
+		if len(notifications) > 1 {
+			t.Fatalf("step %d: %d notifications", i, len(notifications))
+		} else if len(notifications) == 1 {
+			got = "triggered"
+			if notifications[0].resolved {
+				got = "resolved"
+			}
+		}
+		if got != step.want {
+			t.Errorf("step %d: %.1f °C after %v gives %q, want %q", i, step.temperature, step.after, got, step.want)
+		}
+	}
+}
+
+func TestAlertScope(t *testing.T) {
+	alerts := map[string]appmodel.Alert{
+		"storm": {Attribute: "wind_speed", Condition: appmodel.RuleAbove, Threshold: 20, Locations: []int64{1}, ProjectIDs: []string{"20"}},
+	}
+	tracker := newAlertTracker()
+	weather := map[string]any{"wind_speed": 25.0}
+
+	for _, tt := range []struct {
+		asset appmodel.Asset
+		want  int
+	}{
+		{asset: appmodel.Asset{ID: 1, ProjectID: "10", AssetID: 100}, want: 1},
+		{asset: appmodel.Asset{ID: 2, ProjectID: "20", AssetID: 200}, want: 1},
+		{asset: appmodel.Asset{ID: 3, ProjectID: "10", AssetID: 300}, want: 0},
+	} {
+		if got := len(tracker.evaluate(tt.asset, weather, time.Now(), alerts)); got != tt.want {
+			t.Errorf("location %d in project %s: %d notifications, want %d", tt.asset.ID, tt.asset.ProjectID, got, tt.want)
+		}
+	}
+}
+
+func TestAlertResolvedOnDeletion(t *testing.T) {
+	storm := appmodel.Alert{Attribute: "wind_speed", Condition: appmodel.RuleAbove, Threshold: 20, Users: []string{"7"}}
+	alerts := map[string]appmodel.Alert{"storm": storm}
+	tracker := newAlertTracker()
+	first := appmodel.Asset{ID: 1, ProjectID: "10", AssetID: 100, LocationName: "Zürich"}
+	second := appmodel.Asset{ID: 2, ProjectID: "10", AssetID: 200, LocationName: "Basel"}
+	now := time.Now()
+	tracker.evaluate(first, map[string]any{"wind_speed": 25.0}, now, alerts)
+	tracker.evaluate(second, map[string]any{"wind_speed": 25.0}, now, alerts)
+
+	// Deleting the alert resolves it with the current value.
+	notifications := tracker.evaluate(first, map[string]any{"wind_speed": 24.0}, now.Add(time.Minute), nil)
+	if len(notifications) != 1 || !notifications[0].resolved || notifications[0].name != "storm" || notifications[0].value != 24 {
+		t.Errorf("notifications after deleting the alert: %+v", notifications)
+	} else if len(notifications[0].alert.Users) != 1 {
+		t.Errorf("resolve of a deleted alert without its users: %+v", notifications[0].alert)
+	}
+	if len(tracker.states[first.AssetID]) != 0 {
+		t.Errorf("state of deleted alert kept: %v", tracker.states[first.AssetID])
+	}
+
+	// Deleting a location resolves its alerts with the last value.
+	resolved := tracker.prune([]appmodel.Asset{first})
+	if len(resolved) != 1 || resolved[0].asset != second || len(resolved[0].notifications) != 1 {
+		t.Fatalf("resolves after deleting a location: %+v", resolved)
+	}
+	if notification := resolved[0].notifications[0]; !notification.resolved || notification.value != 25 {
+		t.Errorf("resolve of deleted location: %+v", notification)
+	}
+	if _, ok := tracker.states[second.AssetID]; ok {
+		t.Error("state of deleted location kept")
+	}
+	if resolved := tracker.prune([]appmodel.Asset{first}); len(resolved) != 0 {
+		t.Errorf("resolves repeated: %+v", resolved)
+	}
+}
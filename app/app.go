@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"reflect"
 	"sync"
@@ -29,6 +30,7 @@ import (
 	"weather-app2/broker"
 	dbhelper "weather-app2/db/helper"
 	"weather-app2/eliona"
+	"weather-app2/metrics"
 
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"github.com/eliona-smart-building-assistant/go-eliona/app"
@@ -42,19 +44,6 @@ import (
 	"github.com/eliona-smart-building-assistant/go-utils/log"
 )
 
-var appStatus = 0
-
-const (
-	statusOK = iota
-	statusError
-	statusFatal
-)
-
-func changeAppStatus(status int) {
-	appStatus = status
-	Heartbeat()
-}
-
 func Initialize() {
 	ctx := context.Background()
 
@@ -69,6 +58,18 @@ func Initialize() {
 		asset.InitAssetTypeFiles("resources/asset-types/*.json"),
 		dashboard.InitWidgetTypeFiles("resources/widget-types/*.json"),
 	)
+
+	checkObservationRetention()
+
+	// Encrypt API keys stored in plain text before encryption was introduced. Once they are encrypted,
+	// this finds nothing to do on later starts.
+	if err := dbhelper.CheckEncryptionKey(); err != nil {
+		log.Warn("dbhelper", "The configuration can't be saved until the app is started with the encryption key: %v", err)
+	} else if encrypted, err := dbhelper.EncryptPlaintextSecrets(ctx); err != nil {
+		log.Error("dbhelper", "encrypting stored API keys: %v", err)
+	} else if encrypted > 0 {
+		log.Info("dbhelper", "Encrypted %d API keys stored in plain text", encrypted)
+	}
 }
 
 func initAssetCategory() func(db.Connection) error {
@@ -91,10 +92,38 @@ func initAssetCategory() func(db.Connection) error {
 					},
 				},
 			}).Execute()
+		if err != nil {
+			return err
+		}
+		_, _, err = client.NewClient().AssetTypesAPI.
+			PutAssetTypeCategory(client.AuthenticationContext()).
+			AssetTypeCategory(api.AssetTypeCategory{
+				Name: "weather-app-pv-array",
+				Translation: *api.NewNullableTranslation(&api.Translation{
+					De: api.PtrString("PV-Anlage"),
+					En: api.PtrString("PV array"),
+				}),
+				Properties: []api.AssetTypeCategoryProperty{
+					pvArrayProperty("pv_peak_power", "Peak power (kWp)", "Spitzenleistung (kWp)"),
+					pvArrayProperty("pv_tilt", "Tilt (°)", "Neigung (°)"),
+					pvArrayProperty("pv_azimuth", "Azimuth (°, 180 = south)", "Azimut (°, 180 = Süden)"),
+					pvArrayProperty("pv_losses", "System losses (%)", "Systemverluste (%)"),
+				},
+			}).Execute()
 		return err
 	}
 }
 
+func pvArrayProperty(name, en, de string) api.AssetTypeCategoryProperty {
+	return api.AssetTypeCategoryProperty{
+		Name: name,
+		Translation: *api.NewNullableTranslation(&api.Translation{
+			En: api.PtrString(en),
+			De: api.PtrString(de),
+		}),
+	}
+}
+
 var (
 	once             sync.Once
 	configChangeChan = make(chan struct{})
@@ -102,17 +131,20 @@ var (
 	configMutex      sync.Mutex
 )
 
+// readConfig is replaced in tests.
+var readConfig = dbhelper.GetConfig
+
 func CollectData() {
-	config, err := dbhelper.GetConfig(context.Background())
+	config, err := readConfig(context.Background())
 	if errors.Is(err, dbhelper.ErrNotFound) {
 		once.Do(func() {
 			log.Info("dbhelper", "No configs in DB. Please configure the app in Eliona.")
 		})
+		stopDeletedConfig()
 		return
 	}
 	if err != nil {
-		log.Fatal("dbhelper", "Couldn't read configs from DB: %v", err)
-		changeAppStatus(statusFatal)
+		exitFatal("dbhelper", fmt.Errorf("reading config: %v", err))
 		return
 	}
 
@@ -150,13 +182,14 @@ func CollectData() {
 	ctx, cancel := context.WithCancel(context.Background())
 	common.RunOnceWithParam(func(config appmodel.Configuration) {
 		log.Info("main", "Collecting %d started.", config.Id)
-		if err := collectResources(ctx, &config); err != nil {
-			changeAppStatus(statusError)
+		result, err := collectResources(ctx, &config)
+		if err != nil {
+			status.reportError(err)
 			cancel() // Cancel the context to stop the long-running processes
 			return   // Error is handled in the method itself.
 		}
-		log.Info("main", "Collecting %d finished.", config.Id)
-		changeAppStatus(statusOK)
+		log.Info("main", "Collecting %d finished, %d of %d locations failed.", config.Id, result.failing, result.locations)
+		status.reportCycle(result)
 
 		// Wait for the next interval or a config change
 		select {
@@ -191,6 +224,18 @@ func isConfigChanged(newConfig appmodel.Configuration) bool {
 	return false
 }
 
+// stopDeletedConfig ends a collection still waiting for its next cycle after the configuration was deleted.
+func stopDeletedConfig() {
+	configMutex.Lock()
+	deleted := len(previousConfigs) > 0
+	clear(previousConfigs)
+	configMutex.Unlock()
+	if deleted {
+		log.Info("app", "Configuration deleted, stopping collection.")
+		triggerReload()
+	}
+}
+
 func triggerReload() {
 	select {
 	case configChangeChan <- struct{}{}:
@@ -200,31 +245,90 @@ func triggerReload() {
 	}
 }
 
-func collectResources(ctx context.Context, config *appmodel.Configuration) error {
+// collectResources updates the weather of all locations. A location that fails does not stop the
+// others; it is counted in the result. Errors affecting all locations are returned.
+func collectResources(ctx context.Context, config *appmodel.Configuration) (cycleResult, error) {
 	if err := createRootAsset(config); err != nil {
 		log.Error("app", "creating root asset for config %v in Eliona: %v", config.Id, err)
-		return err
+		return cycleResult{}, err
 	}
 
 	assets, err := dbhelper.GetAssets(ctx)
 	if err != nil {
 		log.Error("dbhelper", "getting assets: %v", err)
-		return err
+		return cycleResult{}, err
 	}
+	start := time.Now()
+	result := cycleResult{locations: len(assets)}
+	arrays := pvArrays()
+	ruleOutputs.syncAttributes(config.Rules, assets, start)
 	for _, asset := range assets {
-		weather, err := broker.GetWeather(asset.Lat, asset.Lon, config.ApiKey)
-		if err != nil {
-			log.Error("broker", "getting weather data: %v", err)
-			return err
+		var pv *appmodel.PVArray
+		if array, ok := arrays[asset.AssetID]; ok {
+			pv = &array
 		}
-		weatherMap := weatherDataToMap(weather)
-		if err := eliona.UpsertData(asset.AssetID, weatherMap, time.Now(), api.SUBTYPE_INPUT); err != nil {
-			log.Error("eliona", "upserting data for asset %v: %v", asset.AssetID, err)
-			return err
+		observedAt, err := collectAsset(config, asset, pv)
+		if err != nil {
+			metrics.AssetFailed()
+			result.failing++
+			result.lastErr = fmt.Errorf("location %s: %v", asset.LocationName, err)
+			continue
 		}
+		staleness.updated(asset.AssetID, observedAt)
+		metrics.AssetProcessed()
+	}
+	result.finished = time.Now()
+	ruleOutputs.prune(assets)
+	plausibility.prune(assets)
+	for _, location := range alertStates.prune(assets) {
+		notifyAlerts(config, location.asset, location.notifications)
+	}
+	deleteExpiredObservations(ctx)
+	changes, staleCount := staleness.evaluate(config, assets, result.finished)
+	markStaleLocations(config, changes)
+	result.stale = staleCount
+	if staleCount > 0 && result.lastErr == nil {
+		result.lastErr = fmt.Errorf("%d of %d locations have stale data", staleCount, len(assets))
 	}
+	result.duration = result.finished.Sub(start)
+	metrics.ObserveCycle(result.duration)
+	return result, nil
+}
 
-	return nil
+// collectAsset updates the weather of a location and returns the observation time reported by the provider.
+// pv is the PV array at the location, if any.
+func collectAsset(config *appmodel.Configuration, asset appmodel.Asset, pv *appmodel.PVArray) (time.Time, error) {
+	weather, err := broker.GetWeatherWithForecast(asset.Lat, asset.Lon, config.ApiKey)
+	if err != nil {
+		log.Error("broker", "getting weather data: %v", err)
+		return time.Time{}, fmt.Errorf("getting weather data: %v", err)
+	}
+	observedAt := time.Unix(weather.Current.Dt, 0)
+	sunTimes.update(asset.ID, weather.Current.Sunrise, weather.Current.Sunset)
+	weatherMap := weatherDataToMap(weather)
+	rejected := plausibility.filter(asset, weatherMap, observedAt, config.PlausibilityLimitsWithDefaults())
+	addDerivedMetrics(config, asset, pv, weather, weatherMap)
+	if err := eliona.UpsertData(asset.AssetID, weatherMap, time.Now(), api.SUBTYPE_INPUT); err != nil {
+		log.Error("eliona", "upserting data for asset %v: %v", asset.AssetID, err)
+		return time.Time{}, fmt.Errorf("upserting data: %v", err)
+	}
+	quality := qualityOK
+	if len(rejected) > 0 {
+		quality = qualityImplausible
+	}
+	storeObservation(asset, observedAt, weatherMap)
+	if outputs := ruleOutputs.evaluate(asset.AssetID, weatherMap, time.Now(), config.Rules); len(outputs) > 0 {
+		if err := eliona.UpsertData(asset.AssetID, outputs, time.Now(), api.SUBTYPE_OUTPUT); err != nil {
+			log.Error("eliona", "setting rule outputs of asset %v: %v", asset.AssetID, err)
+		}
+	}
+	notifyAlerts(config, asset, alertStates.evaluate(asset, weatherMap, time.Now(), config.Alerts))
+	if plausibility.qualityChanged(asset.AssetID, quality) {
+		if err := eliona.UpsertData(asset.AssetID, map[string]any{"data_quality": quality}, time.Now(), api.SUBTYPE_STATUS); err != nil {
+			log.Error("eliona", "setting data quality of asset %v: %v", asset.AssetID, err)
+		}
+	}
+	return observedAt, nil
 }
 
 func weatherDataToMap(data broker.WeatherData) map[string]any {
@@ -238,9 +342,37 @@ func weatherDataToMap(data broker.WeatherData) map[string]any {
 	weatherMap["clouds"] = data.Current.Clouds
 	weatherMap["wind_speed"] = data.Current.WindSpeed
 	weatherMap["wind_deg"] = data.Current.WindDeg
+	weatherMap["wind_gust"] = data.Current.WindGust
+	addForecastToMap(weatherMap, data.Hourly, time.Unix(data.Current.Dt, 0))
 	return weatherMap
 }
 
+// forecastHorizons are the hours ahead for which the forecast is provided as attributes.
+var forecastHorizons = []int{3, 6, 12, 24, 48}
+
+func addForecastToMap(weatherMap map[string]any, hourly []broker.HourlyWeather, now time.Time) {
+	if len(hourly) == 0 {
+		return
+	}
+	for _, hours := range forecastHorizons {
+		forecast := closestForecast(hourly, now.Add(time.Duration(hours)*time.Hour))
+		weatherMap[fmt.Sprintf("forecast_temperature_%dh", hours)] = forecast.Temp
+		weatherMap[fmt.Sprintf("forecast_precipitation_%dh", hours)] = forecast.Pop * 100
+	}
+}
+
+// closestForecast returns the hourly forecast closest to the given time. The provider
+// delivers 48 hours, so the furthest horizon gets the last one available.
+func closestForecast(hourly []broker.HourlyWeather, at time.Time) broker.HourlyWeather {
+	closest := hourly[0]
+	for _, h := range hourly[1:] {
+		if math.Abs(float64(h.Dt-at.Unix())) < math.Abs(float64(closest.Dt-at.Unix())) {
+			closest = h
+		}
+	}
+	return closest
+}
+
 func createRootAsset(config *appmodel.Configuration) error {
 	if hasRoot, err := dbhelper.RootAssetAlreadyCreated(); err != nil {
 		return fmt.Errorf("finding whether config already has root asset: %v", err)
@@ -259,11 +391,12 @@ func createRootAsset(config *appmodel.Configuration) error {
 // ListenForOutputChanges listens to output attribute changes from Eliona. Delete if not needed.
 func ListenForOutputChanges() {
 	for {
-		outputs, err := eliona.ListenForPropertyChanges()
+		outputs, err := eliona.ListenForPropertyChanges(catchUpLocationChanges)
 		if err != nil {
 			log.Error("eliona", "listening for output changes: %v", err)
-			changeAppStatus(statusError)
-			return
+			status.reportError(fmt.Errorf("listening for location changes: %v", err))
+			time.Sleep(time.Second * 5)
+			continue
 		}
 
 		for output := range outputs {
@@ -278,18 +411,65 @@ func ListenForOutputChanges() {
 				continue
 			} else if err != nil {
 				log.Error("dbhelper", "getting asset by assetID %v: %v", output.AssetId, err)
-				changeAppStatus(statusError)
-				return
+				status.reportError(fmt.Errorf("getting asset %v: %v", output.AssetId, err))
+				continue
 			}
 
 			handleExistingAsset(output, asset)
 			triggerReload()
 		}
 
+		log.Info("eliona", "Listening for output changes stopped, restarting.")
 		time.Sleep(time.Second * 5)
 	}
 }
 
+// catchUpLocationChanges compares the location names of all weather assets in Eliona with the
+// ones known to the app and handles those that changed while the app was not listening.
+func catchUpLocationChanges() {
+	if _, err := dbhelper.GetConfig(context.Background()); errors.Is(err, dbhelper.ErrNotFound) {
+		// Without configuration there is no API key to locate anything yet.
+		return
+	} else if err != nil {
+		log.Error("dbhelper", "getting config: %v", err)
+		return
+	}
+
+	properties, err := eliona.GetAssetTypeData(eliona.WeatherAssetType, api.SUBTYPE_PROPERTY)
+	if err != nil {
+		log.Error("eliona", "getting location names of weather assets: %v", err)
+		return
+	}
+
+	changed := false
+	for _, property := range properties {
+		locationName, ok := property.Data["name"].(string)
+		if !ok || locationName == "" {
+			continue
+		}
+
+		asset, err := dbhelper.GetAssetById(property.AssetId)
+		if errors.Is(err, dbhelper.ErrNotFound) {
+			handleNewAsset(property)
+			changed = true
+			continue
+		} else if err != nil {
+			log.Error("dbhelper", "getting asset by assetID %v: %v", property.AssetId, err)
+			return
+		}
+
+		if asset.LocationName == locationName {
+			continue
+		}
+		handleExistingAsset(property, asset)
+		changed = true
+	}
+
+	if changed {
+		triggerReload()
+	}
+}
+
 func handleNewAsset(output api.Data) {
 	log.Debug("app", "received data update for new asset %v: %+v", output.AssetId, output)
 
@@ -299,7 +479,7 @@ func handleNewAsset(output api.Data) {
 		return
 	}
 
-	if elionaAsset.AssetType != "weather_app_weather" {
+	if elionaAsset.AssetType != eliona.WeatherAssetType {
 		log.Debug("eliona", "this asset is not ours")
 		return
 	}
@@ -312,7 +492,7 @@ func handleNewAsset(output api.Data) {
 	config, err := dbhelper.GetConfig(context.Background())
 	if err != nil {
 		log.Error("dbhelper", "getting config: %v", err)
-		changeAppStatus(statusError)
+		status.reportError(fmt.Errorf("getting config: %v", err))
 		return
 	}
 
@@ -322,7 +502,7 @@ func handleNewAsset(output api.Data) {
 		return
 	}
 
-	locationNameFormatted := formatLocationName(location)
+	locationNameFormatted := location.FormattedName()
 
 	if err := eliona.UpsertData(elionaAsset.GetId(), map[string]any{"name": locationNameFormatted}, time.Now(), api.SUBTYPE_PROPERTY); err != nil {
 		log.Error("eliona", "updating asset %v location name: %v", elionaAsset.GetId(), err)
@@ -344,14 +524,15 @@ func handleExistingAsset(output api.Data, asset appmodel.Asset) {
 	log.Debug("app", "received data update for known asset %v: %+v", output.AssetId, output)
 
 	locationName, ok := getLocationName(output.Data)
-	if !ok {
+	if !ok || locationName == asset.LocationName {
+		// Other properties such as the PV array changed, the location stays.
 		return
 	}
 
 	config, err := dbhelper.GetConfig(context.Background())
 	if err != nil {
 		log.Error("dbhelper", "getting config: %v", err)
-		changeAppStatus(statusError)
+		status.reportError(fmt.Errorf("getting config: %v", err))
 		return
 	}
 
@@ -361,7 +542,7 @@ func handleExistingAsset(output api.Data, asset appmodel.Asset) {
 		return
 	}
 
-	locationNameFormatted := formatLocationName(location)
+	locationNameFormatted := location.FormattedName()
 
 	if err := eliona.UpsertData(asset.AssetID, map[string]any{"name": locationNameFormatted}, time.Now(), api.SUBTYPE_PROPERTY); err != nil {
 		log.Error("eliona", "updating asset %v location name: %v", asset.AssetID, err)
@@ -394,10 +575,6 @@ func getLocationName(data map[string]interface{}) (string, bool) {
 	return locationName, true
 }
 
-func formatLocationName(location broker.Geolocation) string {
-	return fmt.Sprintf("%s, %s, %s", location.Name, location.State, location.Country)
-}
-
 func Heartbeat() {
 	roots, err := dbhelper.GetRootAssets()
 	if err != nil {
@@ -406,7 +583,7 @@ func Heartbeat() {
 	}
 
 	for _, root := range roots {
-		err := eliona.UpsertData(root.AssetID, map[string]any{"status": appStatus}, time.Now(), api.SUBTYPE_STATUS)
+		err := eliona.UpsertData(root.AssetID, status.rootData(), time.Now(), api.SUBTYPE_STATUS)
 		if err != nil {
 			log.Error("eliona", "upserting data as heartbeat: %v", err)
 			return
@@ -416,14 +593,18 @@ func Heartbeat() {
 
 // ListenApi starts the API server and listen for requests
 func ListenApi() {
+	router := apiserver.NewRouter(
+		apiserver.NewConfigurationAPIController(apiservices.NewConfigurationAPIService()),
+		apiserver.NewVersionAPIController(apiservices.NewVersionAPIService()),
+		apiserver.NewCustomizationAPIController(apiservices.NewCustomizationAPIService()),
+		apiserver.NewLocationsAPIController(apiservices.NewLocationsAPIService()),
+		apiserver.NewWeatherAPIController(apiservices.NewWeatherAPIService()),
+	)
+	router.Handle("/metrics", metrics.Handler()).Methods(http.MethodGet)
+	router.HandleFunc("/health/live", handleLiveness).Methods(http.MethodGet)
+	router.HandleFunc("/health/ready", handleReadiness).Methods(http.MethodGet)
 	err := http.ListenAndServe(":"+common.Getenv("API_SERVER_PORT", "3000"),
 		frontend.NewEnvironmentHandler(
-			utilshttp.NewCORSEnabledHandler(
-				apiserver.NewRouter(
-					apiserver.NewConfigurationAPIController(apiservices.NewConfigurationAPIService()),
-					apiserver.NewVersionAPIController(apiservices.NewVersionAPIService()),
-					apiserver.NewCustomizationAPIController(apiservices.NewCustomizationAPIService()),
-				))))
-	log.Fatal("main", "API server: %v", err)
-	changeAppStatus(statusFatal)
+			utilshttp.NewCORSEnabledHandler(router)))
+	exitFatal("main", fmt.Errorf("API server: %v", err))
 }
@@ -0,0 +1,206 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import "math"
+
+// Apparent temperatures in °C. Each model is only defined for part of the weather and reports false outside
+// of it, so that e.g. no wind chill is reported in summer.
+
+// heatIndex returns the heat index after the US National Weather Service, which combines the Steadman
+// approximation with the Rothfusz regression and its adjustments. It applies from 26.7 °C (80 °F).
+func heatIndex(temperature, humidity float64) (float64, bool) {
+	if temperature < 26.7 {
+		return 0, false
+	}
+	t := temperature*9/5 + 32
+	hi := 0.5 * (t + 61 + (t-68)*1.2 + humidity*0.094)
+	if (hi+t)/2 >= 80 {
+		hi = -42.379 + 2.04901523*t + 10.14333127*humidity -
+			0.22475541*t*humidity - 0.00683783*t*t - 0.05481717*humidity*humidity +
+			0.00122874*t*t*humidity + 0.00085282*t*humidity*humidity - 0.00000199*t*t*humidity*humidity
+		if humidity < 13 && t <= 112 {
+			hi -= (13 - humidity) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+		} else if humidity > 85 && t <= 87 {
+			hi += (humidity - 85) / 10 * (87 - t) / 5
+		}
+	}
+	return math.Max((hi-32)*5/9, temperature), true
+}
+
+// windChill returns the wind chill temperature after the JAG/TI formula used in Canada and the US, with the
+// wind speed in m/s at 10 m. It applies up to 10 °C and above 4.8 km/h.
+func windChill(temperature, windSpeed float64) (float64, bool) {
+	kmh := windSpeed * 3.6
+	if temperature > 10 || kmh <= 4.8 {
+		return 0, false
+	}
+	v := math.Pow(kmh, 0.16)
+	return math.Min(13.12+0.6215*temperature-11.37*v+0.3965*temperature*v, temperature), true
+}
+
+// humidex returns the humidex after Environment Canada from the dew point. It applies from 20 °C.
+func humidex(temperature, dewPoint float64) (float64, bool) {
+	if temperature < 20 {
+		return 0, false
+	}
+	e := 6.11 * math.Exp(5417.7530*(1/273.16-1/(dewPoint+273.15)))
+	return math.Max(temperature+0.5555*(e-10), temperature), true
+}
+
+// utci returns the Universal Thermal Climate Index after the operational polynomial of Bröde et al. (2012)
+// with the wind speed in m/s at 10 m. Without the mean radiant temperature it is the UTCI in the shade,
+// where the mean radiant temperature equals the air temperature. Calm wind counts as the model's lowest
+// wind speed of 0.5 m/s. It applies from -50 to 50 °C, up to 17 m/s and up to a vapor pressure of 5 kPa.
+func utci(temperature, humidity, windSpeed float64) (float64, bool) {
+	pa := vaporPressure(temperature, humidity) / 10
+	if temperature < -50 || temperature > 50 || windSpeed > 17 || pa > 5 {
+		return 0, false
+	}
+	va := math.Max(windSpeed, 0.5)
+	offset := 0.0
+	for _, term := range utciTerms {
+		offset += term.coefficient * math.Pow(temperature, float64(term.ta)) * math.Pow(va, float64(term.va)) * math.Pow(pa, float64(term.pa))
+	}
+	return temperature + offset, true
+}
+
+// utciTerms are the terms of the UTCI polynomial that remain if the mean radiant temperature equals the air
+// temperature, as coefficient and powers of the air temperature in °C, the wind speed in m/s and the vapor
+// pressure in kPa.
+var utciTerms = []struct {
+	coefficient float64
+	ta, va, pa  int
+}{
+	{6.07562052e-01, 0, 0, 0},
+	{-2.27712343e-02, 1, 0, 0},
+	{8.06470249e-04, 2, 0, 0},
+	{-1.54271372e-04, 3, 0, 0},
+	{-3.24651735e-06, 4, 0, 0},
+	{7.32602852e-08, 5, 0, 0},
+	{1.35959073e-09, 6, 0, 0},
+	{-2.25836520e+00, 0, 1, 0},
+	{8.80326035e-02, 1, 1, 0},
+	{2.16844454e-03, 2, 1, 0},
+	{-1.53347087e-05, 3, 1, 0},
+	{-5.72983704e-07, 4, 1, 0},
+	{-2.55090145e-09, 5, 1, 0},
+	{-7.51269505e-01, 0, 2, 0},
+	{-4.08350271e-03, 1, 2, 0},
+	{-5.21670675e-05, 2, 2, 0},
+	{1.94544667e-06, 3, 2, 0},
+	{1.14099531e-08, 4, 2, 0},
+	{1.58137256e-01, 0, 3, 0},
+	{-6.57263143e-05, 1, 3, 0},
+	{2.22697524e-07, 2, 3, 0},
+	{-4.16117031e-08, 3, 3, 0},
+	{-1.27762753e-02, 0, 4, 0},
+	{9.66891875e-06, 1, 4, 0},
+	{2.52785852e-09, 2, 4, 0},
+	{4.56306672e-04, 0, 5, 0},
+	{-1.74202546e-07, 1, 5, 0},
+	{-5.91491269e-06, 0, 6, 0},
+	{5.12733497e+00, 0, 0, 1},
+	{-3.12788561e-01, 1, 0, 1},
+	{-1.96701861e-02, 2, 0, 1},
+	{9.99690870e-04, 3, 0, 1},
+	{9.51738512e-06, 4, 0, 1},
+	{-4.66426341e-07, 5, 0, 1},
+	{5.48050612e-01, 0, 1, 1},
+	{-3.30552823e-03, 1, 1, 1},
+	{-1.64119440e-03, 2, 1, 1},
+	{-5.16670694e-06, 3, 1, 1},
+	{9.52692432e-07, 4, 1, 1},
+	{-4.29223622e-02, 0, 2, 1},
+	{5.00845667e-03, 1, 2, 1},
+	{1.00601257e-06, 2, 2, 1},
+	{-1.81748644e-06, 3, 2, 1},
+	{-1.25813502e-03, 0, 3, 1},
+	{-1.79330391e-04, 1, 3, 1},
+	{2.34994441e-06, 2, 3, 1},
+	{1.29735808e-04, 0, 4, 1},
+	{1.29064870e-06, 1, 4, 1},
+	{-2.28558686e-06, 0, 5, 1},
+	{-2.80626406e+00, 0, 0, 2},
+	{5.48712484e-01, 1, 0, 2},
+	{-3.99428410e-03, 2, 0, 2},
+	{-9.54009191e-04, 3, 0, 2},
+	{1.93090978e-05, 4, 0, 2},
+	{-3.08806365e-01, 0, 1, 2},
+	{1.16952364e-02, 1, 1, 2},
+	{4.95271903e-04, 2, 1, 2},
+	{-1.90710882e-05, 3, 1, 2},
+	{2.10787756e-03, 0, 2, 2},
+	{-6.98445738e-04, 1, 2, 2},
+	{2.30109073e-05, 2, 2, 2},
+	{4.17856590e-04, 0, 3, 2},
+	{-1.27043871e-05, 1, 3, 2},
+	{-3.04620472e-06, 0, 4, 2},
+	{-3.53874123e-02, 0, 0, 3},
+	{-2.21201190e-01, 1, 0, 3},
+	{1.55126038e-02, 2, 0, 3},
+	{-2.63917279e-04, 3, 0, 3},
+	{4.53433455e-02, 0, 1, 3},
+	{-4.32943862e-03, 1, 1, 3},
+	{1.45389826e-04, 2, 1, 3},
+	{2.17508610e-04, 0, 2, 3},
+	{-6.66724702e-05, 1, 2, 3},
+	{3.33217140e-05, 0, 3, 3},
+	{6.14155345e-01, 0, 0, 4},
+	{-6.16755931e-02, 1, 0, 4},
+	{1.33374846e-03, 2, 0, 4},
+	{3.55375387e-03, 0, 1, 4},
+	{-5.13027851e-04, 1, 1, 4},
+	{1.02449757e-04, 0, 2, 4},
+	{8.82773108e-02, 0, 0, 5},
+	{-3.01859306e-03, 1, 0, 5},
+	{1.04452989e-03, 0, 1, 5},
+	{1.48348065e-03, 0, 0, 6},
+}
+
+// addComfortIndices sets the apparent temperature attributes in weatherMap for which the inputs passed the
+// plausibility check. Where an index is not defined for the current weather, it is set to the air
+// temperature, so that rules and alerts on it don't hold a value from another season.
+func addComfortIndices(weatherMap map[string]any) {
+	temperature, ok := toFloat(weatherMap["temperature"])
+	if !ok {
+		return
+	}
+	set := func(attribute string, value float64, defined bool) {
+		if !defined {
+			value = temperature
+		}
+		weatherMap[attribute] = value
+	}
+	humidity, humidityOK := toFloat(weatherMap["humidity"])
+	windSpeed, windSpeedOK := toFloat(weatherMap["wind_speed"])
+	if humidityOK {
+		value, defined := heatIndex(temperature, humidity)
+		set("heat_index", value, defined)
+	}
+	if windSpeedOK {
+		value, defined := windChill(temperature, windSpeed)
+		set("wind_chill", value, defined)
+	}
+	if dewPoint, ok := toFloat(weatherMap["dew_point"]); ok {
+		value, defined := humidex(temperature, dewPoint)
+		set("humidex", value, defined)
+	}
+	if humidityOK && windSpeedOK {
+		value, defined := utci(temperature, humidity, windSpeed)
+		set("utci", value, defined)
+	}
+}
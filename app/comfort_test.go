@@ -0,0 +1,128 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+// Reference values from the NWS heat index chart, the Environment Canada wind chill and humidex tables and
+// the UTCI reference implementation.
+func TestComfortIndices(t *testing.T) {
+	fahrenheit := func(f float64) float64 { return (f - 32) * 5 / 9 }
+	index := func(value float64, ok bool) func() (float64, bool) {
+		return func() (float64, bool) { return value, ok }
+	}
+
+	tests := []struct {
+		name  string
+		index func() (float64, bool)
+		want  float64
+		ok    bool
+	}{
+		{name: "heat index 90 °F 70 %", index: index(heatIndex(fahrenheit(90), 70)), want: fahrenheit(105), ok: true},
+		{name: "heat index 100 °F 40 %", index: index(heatIndex(fahrenheit(100), 40)), want: fahrenheit(109), ok: true},
+		{name: "heat index 84 °F 90 %", index: index(heatIndex(fahrenheit(84), 90)), want: fahrenheit(98), ok: true},
+		{name: "heat index below range", index: index(heatIndex(22, 90))},
+		{name: "wind chill -10 °C 20 km/h", index: index(windChill(-10, 20/3.6)), want: -17.9, ok: true},
+		{name: "wind chill -25 °C 40 km/h", index: index(windChill(-25, 40/3.6)), want: -41, ok: true},
+		{name: "wind chill in summer", index: index(windChill(25, 10))},
+		{name: "wind chill calm", index: index(windChill(-5, 1))},
+		{name: "humidex 30 °C dew point 15 °C", index: index(humidex(30, 15)), want: 33.9, ok: true},
+		{name: "humidex 35 °C dew point 25 °C", index: index(humidex(35, 25)), want: 47.8, ok: true},
+		{name: "humidex below range", index: index(humidex(15, 14))},
+		{name: "UTCI 25 °C 50 % 1 m/s", index: index(utci(25, 50, 1)), want: 24.6, ok: true},
+		{name: "UTCI -10 °C 70 % 10 m/s", index: index(utci(-10, 70, 10)), want: -39.7, ok: true},
+		{name: "UTCI calm", index: index(utci(20, 50, 0)), want: 20, ok: true},
+		{name: "UTCI storm", index: index(utci(0, 50, 20))},
+		{name: "UTCI above range", index: index(utci(55, 10, 1))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.index()
+			if ok != tt.ok {
+				t.Fatalf("%s defined = %v, want %v", tt.name, ok, tt.ok)
+			}
+			if ok {
+				assertNear(t, tt.name, got, tt.want, 0.6)
+			}
+		})
+	}
+}
+
+// The UTCI equals the air temperature in its reference environment: wind of 0.5 m/s, 50 % humidity up to a
+// vapor pressure of 20 hPa and the mean radiant temperature equal to the air temperature. The polynomial
+// approximates it within about 1 K.
+func TestUTCIReferenceEnvironment(t *testing.T) {
+	for temperature := -45.0; temperature <= 45; temperature += 5 {
+		humidity := math.Min(50, 2000/saturationVaporPressure(temperature))
+		got, ok := utci(temperature, humidity, 0.5)
+		if !ok {
+			t.Errorf("UTCI at %v °C not defined", temperature)
+			continue
+		}
+		assertNear(t, fmt.Sprintf("UTCI at %v °C", temperature), got, temperature, 1.2)
+	}
+}
+
+func TestAddComfortIndices(t *testing.T) {
+	tests := []struct {
+		name       string
+		weatherMap map[string]any
+		// undefined are the indices that are not defined for the weather and equal the air temperature.
+		undefined []string
+	}{
+		{
+			name:       "summer",
+			weatherMap: map[string]any{"temperature": 32.0, "humidity": 50.0, "wind_speed": 3.0, "dew_point": 20.0},
+			undefined:  []string{"wind_chill"},
+		},
+		{
+			name:       "winter",
+			weatherMap: map[string]any{"temperature": -5.0, "humidity": 80.0, "wind_speed": 5.0, "dew_point": -7.0},
+			undefined:  []string{"heat_index", "humidex"},
+		},
+		{
+			name:       "mild",
+			weatherMap: map[string]any{"temperature": 15.0, "humidity": 60.0, "wind_speed": 3.0, "dew_point": 7.0},
+			undefined:  []string{"heat_index", "wind_chill", "humidex"},
+		},
+		{
+			name:       "storm",
+			weatherMap: map[string]any{"temperature": 5.0, "humidity": 90.0, "wind_speed": 25.0, "dew_point": 3.5},
+			undefined:  []string{"heat_index", "humidex", "utci"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addComfortIndices(tt.weatherMap)
+			temperature := tt.weatherMap["temperature"].(float64)
+			for _, attribute := range []string{"heat_index", "wind_chill", "humidex", "utci"} {
+				value, ok := tt.weatherMap[attribute].(float64)
+				if !ok {
+					t.Errorf("%s not set", attribute)
+					continue
+				}
+				if undefined := slices.Contains(tt.undefined, attribute); undefined != (value == temperature) {
+					t.Errorf("%s = %v at %v °C, want the air temperature only if undefined (%v)", attribute, value, temperature, undefined)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+	appmodel "weather-app2/app/model"
+	dbhelper "weather-app2/db/helper"
+)
+
+// minDegreeDayRetention is the observation history the monthly degree days need on the last day of a
+// long month.
+const minDegreeDayRetention = 31 * 24 * time.Hour
+
+// degreeDayTracker keeps the mean temperature of completed days per asset. These do not change anymore,
+// so a cycle only reads the observations of the current day from the observation history.
+type degreeDayTracker struct {
+	mu sync.Mutex
+	// means holds the mean temperature by local midnight in Unix seconds for each asset. Days without
+	// observations are NaN.
+	means map[int64]map[int64]float64
+	// observations reads the observation history of an asset.
+	observations func(ctx context.Context, assetID int64, from, to time.Time) ([]appmodel.Observation, error)
+}
+
+var degreeDays = &degreeDayTracker{
+	means:        make(map[int64]map[int64]float64),
+	observations: dbhelper.GetObservations,
+}
+
+// add sets the heating and cooling degree days of the current day so far, the previous day and the month
+// to date in weatherMap. Days are local days of the location, their means are taken over the stored
+// observations and the current one.
+func (t *degreeDayTracker) add(ctx context.Context, asset appmodel.Asset, weatherMap map[string]any, observedAt time.Time, tz *time.Location, base appmodel.DegreeDayBase) error {
+	local := observedAt.In(tz)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, tz)
+	yesterday := today.AddDate(0, 0, -1)
+	monthStart := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, tz)
+	first := monthStart
+	if yesterday.Before(first) {
+		first = yesterday
+	}
+
+	from, means := t.uncachedFrom(asset.ID, first, today)
+	observations, err := t.observations(ctx, asset.ID, from, observedAt)
+	if err != nil {
+		return err
+	}
+	temperatures := make(map[time.Time][]float64)
+	for _, o := range observations {
+		if temperature, ok := o.Data["temperature"]; ok {
+			day := localMidnight(o.ObservedAt, tz)
+			temperatures[day] = append(temperatures[day], temperature)
+		}
+	}
+	if temperature, ok := toFloat(weatherMap["temperature"]); ok {
+		temperatures[today] = append(temperatures[today], temperature)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for day := from; day.Before(today); day = day.AddDate(0, 0, 1) {
+		means[day.Unix()] = mean(temperatures[day])
+	}
+
+	var heatingMonth, coolingMonth float64
+	for day, m := range means {
+		if day >= monthStart.Unix() && !math.IsNaN(m) {
+			heatingMonth += base.HeatingDegrees(m)
+			coolingMonth += base.CoolingDegrees(m)
+		}
+	}
+	if m, ok := means[yesterday.Unix()]; ok && !math.IsNaN(m) {
+		weatherMap["heating_degree_days_yesterday"] = base.HeatingDegrees(m)
+		weatherMap["cooling_degree_days_yesterday"] = base.CoolingDegrees(m)
+	}
+	if m := mean(temperatures[today]); !math.IsNaN(m) {
+		weatherMap["heating_degree_days_today"] = base.HeatingDegrees(m)
+		weatherMap["cooling_degree_days_today"] = base.CoolingDegrees(m)
+		heatingMonth += base.HeatingDegrees(m)
+		coolingMonth += base.CoolingDegrees(m)
+	}
+	weatherMap["heating_degree_days_month"] = heatingMonth
+	weatherMap["cooling_degree_days_month"] = coolingMonth
+	return nil
+}
+
+// uncachedFrom drops the cached days of the asset before first and returns the first day from which the
+// observations must be read, along with the asset's cache. Today is never cached.
+func (t *degreeDayTracker) uncachedFrom(assetID int64, first, today time.Time) (time.Time, map[int64]float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	means, ok := t.means[assetID]
+	if !ok {
+		means = make(map[int64]float64)
+		t.means[assetID] = means
+	}
+	for day := range means {
+		if day < first.Unix() {
+			delete(means, day)
+		}
+	}
+	for day := first; day.Before(today); day = day.AddDate(0, 0, 1) {
+		if _, ok := means[day.Unix()]; !ok {
+			return day, means
+		}
+	}
+	return today, means
+}
+
+func localMidnight(t time.Time, tz *time.Location) time.Time {
+	local := t.In(tz)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, tz)
+}
+
+// mean returns the arithmetic mean of the values, or NaN if there are none.
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,210 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+	appmodel "weather-app2/app/model"
+)
+
+var classicDegreeDayBase = appmodel.DegreeDayBase{HeatingBase: 18, HeatingLimit: 18, CoolingBase: 22}
+
+func TestDegreeDayBase(t *testing.T) {
+	config := appmodel.Configuration{DegreeDayBases: map[string]appmodel.DegreeDayBase{"10": classicDegreeDayBase}}
+	if base := config.DegreeDayBaseFor("10"); base != classicDegreeDayBase {
+		t.Errorf("base of project 10 = %+v, want %+v", base, classicDegreeDayBase)
+	}
+	if base := config.DegreeDayBaseFor("20"); base != appmodel.DefaultDegreeDayBase {
+		t.Errorf("base of project 20 = %+v, want the default %+v", base, appmodel.DefaultDegreeDayBase)
+	}
+
+	tests := []struct {
+		name    string
+		base    appmodel.DegreeDayBase
+		mean    float64
+		heating float64
+		cooling float64
+	}{
+		{name: "SIA heating day", base: appmodel.DefaultDegreeDayBase, mean: 5, heating: 15},
+		{name: "SIA at heating limit", base: appmodel.DefaultDegreeDayBase, mean: 12},
+		{name: "SIA between limit and cooling base", base: appmodel.DefaultDegreeDayBase, mean: 15},
+		{name: "SIA cooling day", base: appmodel.DefaultDegreeDayBase, mean: 24, cooling: 6},
+		{name: "classic heating day", base: classicDegreeDayBase, mean: 15, heating: 3},
+		{name: "classic frost day", base: classicDegreeDayBase, mean: -4.5, heating: 22.5},
+		{name: "classic cooling day", base: classicDegreeDayBase, mean: 25, cooling: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertNear(t, "heating degrees", tt.base.HeatingDegrees(tt.mean), tt.heating, 1e-9)
+			assertNear(t, "cooling degrees", tt.base.CoolingDegrees(tt.mean), tt.cooling, 1e-9)
+		})
+	}
+}
+
+// fakeObservations is an observation history with hourly temperatures from start to end, given per day.
+type fakeObservations struct {
+	start, end  time.Time
+	temperature func(day time.Time) (float64, bool)
+	// reads are the start times of the ranges read.
+	reads []time.Time
+}
+
+func (f *fakeObservations) get(_ context.Context, _ int64, from, to time.Time) ([]appmodel.Observation, error) {
+	f.reads = append(f.reads, from)
+	var observations []appmodel.Observation
+	for at := f.start; at.Before(f.end); at = at.Add(time.Hour) {
+		if at.Before(from) || !at.Before(to) {
+			continue
+		}
+		if temperature, ok := f.temperature(localMidnight(at, time.UTC)); ok {
+			observations = append(observations, appmodel.Observation{ObservedAt: at, Data: map[string]float64{"temperature": temperature}})
+		}
+	}
+	return observations, nil
+}
+
+func TestDegreeDayTotals(t *testing.T) {
+	constant := func(temperature float64) func(time.Time) (float64, bool) {
+		return func(time.Time) (float64, bool) { return temperature, true }
+	}
+	tests := []struct {
+		name        string
+		observedAt  time.Time
+		temperature func(day time.Time) (float64, bool)
+		base        appmodel.DegreeDayBase
+		want        map[string]float64
+	}{
+		{
+			name:        "mid month",
+			observedAt:  time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC),
+			temperature: constant(10),
+			base:        appmodel.DefaultDegreeDayBase,
+			want: map[string]float64{
+				"heating_degree_days_today": 10, "heating_degree_days_yesterday": 10, "heating_degree_days_month": 100,
+				"cooling_degree_days_today": 0, "cooling_degree_days_yesterday": 0, "cooling_degree_days_month": 0,
+			},
+		},
+		{
+			name:       "day without observations",
+			observedAt: time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC),
+			temperature: func(day time.Time) (float64, bool) {
+				return 10, day.Day() != 5
+			},
+			base: appmodel.DefaultDegreeDayBase,
+			want: map[string]float64{"heating_degree_days_month": 90},
+		},
+		{
+			name:       "changing means",
+			observedAt: time.Date(2024, 7, 3, 12, 0, 0, 0, time.UTC),
+			temperature: func(day time.Time) (float64, bool) {
+				return map[int]float64{1: 20, 2: 26, 3: 24}[day.Day()], true
+			},
+			base: classicDegreeDayBase,
+			want: map[string]float64{
+				"cooling_degree_days_today": 2, "cooling_degree_days_yesterday": 4, "cooling_degree_days_month": 6,
+				"heating_degree_days_month": 0,
+			},
+		},
+		{
+			name:        "new year",
+			observedAt:  time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			temperature: constant(-2),
+			base:        appmodel.DefaultDegreeDayBase,
+			want: map[string]float64{
+				"heating_degree_days_today": 22, "heating_degree_days_yesterday": 22, "heating_degree_days_month": 22,
+			},
+		},
+		{
+			name:        "end of long month",
+			observedAt:  time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC),
+			temperature: constant(0),
+			base:        appmodel.DefaultDegreeDayBase,
+			want:        map[string]float64{"heating_degree_days_month": 31 * 20},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := &fakeObservations{
+				start:       tt.observedAt.AddDate(0, -2, 0),
+				end:         tt.observedAt,
+				temperature: tt.temperature,
+			}
+			tracker := &degreeDayTracker{means: make(map[int64]map[int64]float64), observations: history.get}
+			temperature, _ := tt.temperature(localMidnight(tt.observedAt, time.UTC))
+			weatherMap := map[string]any{"temperature": temperature}
+			asset := appmodel.Asset{ID: 1}
+			if err := tracker.add(context.Background(), asset, weatherMap, tt.observedAt, time.UTC, tt.base); err != nil {
+				t.Fatal(err)
+			}
+			for attribute, want := range tt.want {
+				got, ok := weatherMap[attribute].(float64)
+				if !ok {
+					t.Errorf("%s missing", attribute)
+					continue
+				}
+				assertNear(t, attribute, got, want, 1e-9)
+			}
+		})
+	}
+}
+
+func TestDegreeDayCache(t *testing.T) {
+	observedAt := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	history := &fakeObservations{
+		start:       observedAt.AddDate(0, -1, 0),
+		end:         observedAt.AddDate(0, 0, 2),
+		temperature: func(time.Time) (float64, bool) { return 10, true },
+	}
+	tracker := &degreeDayTracker{means: make(map[int64]map[int64]float64), observations: history.get}
+	asset := appmodel.Asset{ID: 1}
+	add := func(at time.Time) map[string]any {
+		weatherMap := map[string]any{"temperature": 10.0}
+		if err := tracker.add(context.Background(), asset, weatherMap, at, time.UTC, appmodel.DefaultDegreeDayBase); err != nil {
+			t.Fatal(err)
+		}
+		return weatherMap
+	}
+
+	add(observedAt)
+	add(observedAt.Add(time.Hour))
+	// The next day only reads the previous one, which was still incomplete, and the new day.
+	add(observedAt.Add(14 * time.Hour))
+	// A new month drops the days before the previous one.
+	history.temperature = func(day time.Time) (float64, bool) { return 10, day.Month() == time.March }
+	history.start, history.end = observedAt.AddDate(0, 0, -10), time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)
+	newMonth := add(time.Date(2024, 4, 1, 6, 0, 0, 0, time.UTC))
+
+	wantReads := []time.Time{
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	if len(history.reads) != len(wantReads) {
+		t.Fatalf("read history from %v, want %v", history.reads, wantReads)
+	}
+	for i, want := range wantReads {
+		if !history.reads[i].Equal(want) {
+			t.Errorf("read %d from %v, want %v", i, history.reads[i], want)
+		}
+	}
+	if cached := len(tracker.means[asset.ID]); cached != 1 {
+		t.Errorf("%d days cached in the new month, want only the previous day", cached)
+	}
+	assertNear(t, "heating degree days yesterday", newMonth["heating_degree_days_yesterday"].(float64), 10, 1e-9)
+}
@@ -0,0 +1,51 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	"time"
+	appmodel "weather-app2/app/model"
+	"weather-app2/broker"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// addDerivedMetrics adds the attributes computed from the provider's data to weatherMap. It runs after the
+// plausibility check, so rejected values are not carried into derived ones.
+func addDerivedMetrics(config *appmodel.Configuration, asset appmodel.Asset, pv *appmodel.PVArray, weather broker.WeatherData, weatherMap map[string]any) {
+	addPsychrometrics(weatherMap)
+	addComfortIndices(weatherMap)
+	addIrradiance(asset, pv, weather, weatherMap)
+	addNowcast(weather, weatherMap)
+	observedAt := time.Unix(weather.Current.Dt, 0)
+	if err := degreeDays.add(context.Background(), asset, weatherMap, observedAt, locationTimezone(weather), config.DegreeDayBaseFor(asset.ProjectID)); err != nil {
+		log.Error("app", "computing degree days for asset %v: %v", asset.AssetID, err)
+	}
+}
+
+// locationTimezone returns the time zone the provider reports for the location, or UTC if it is unknown.
+func locationTimezone(weather broker.WeatherData) *time.Location {
+	if weather.Timezone == "" {
+		return time.UTC
+	}
+	tz, err := time.LoadLocation(weather.Timezone)
+	if err != nil {
+		log.Warn("app", "unknown time zone %q, using UTC: %v", weather.Timezone, err)
+		return time.UTC
+	}
+	return tz
+}
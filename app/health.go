@@ -0,0 +1,118 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"weather-app2/broker"
+	dbhelper "weather-app2/db/helper"
+	"weather-app2/eliona"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// maxMissedCycles is the number of refresh intervals after which the last successful cycle is too old.
+const maxMissedCycles = 3
+
+// healthCheckTimeout limits how long the readiness checks of the database and the Eliona API may take.
+const healthCheckTimeout = 5 * time.Second
+
+// startedAt gives the first cycle time to succeed before readiness fails.
+var startedAt = time.Now()
+
+type healthCheck struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type healthReport struct {
+	Status string                 `json:"status"`
+	Checks map[string]healthCheck `json:"checks,omitempty"`
+}
+
+// handleLiveness answers as long as the API server is running.
+func handleLiveness(w http.ResponseWriter, _ *http.Request) {
+	writeHealthReport(w, healthReport{Status: "ok"})
+}
+
+// handleReadiness checks the dependencies the app needs to collect weather data.
+func handleReadiness(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+	defer cancel()
+
+	report := healthReport{Status: "ok", Checks: make(map[string]healthCheck)}
+	check := func(name string, err error) {
+		if err != nil {
+			report.Status = "fail"
+			report.Checks[name] = healthCheck{Status: "fail", Error: err.Error()}
+			return
+		}
+		report.Checks[name] = healthCheck{Status: "ok"}
+	}
+
+	check("database", dbhelper.Ping(ctx))
+	check("eliona", eliona.CheckAPI(ctx))
+	if !eliona.WebsocketConnected() {
+		check("websocket", errors.New("not connected to Eliona"))
+	} else {
+		check("websocket", nil)
+	}
+	check("provider", broker.AuthenticationError())
+	check("cycle", checkCycleAge(ctx))
+
+	writeHealthReport(w, report)
+}
+
+// checkCycleAge fails if the last successful cycle is older than maxMissedCycles refresh intervals.
+// Without an enabled configuration there is no collection to check.
+func checkCycleAge(ctx context.Context) error {
+	config, err := dbhelper.GetConfig(ctx)
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting config: %v", err)
+	}
+	if !config.Enable {
+		return nil
+	}
+	maxAge := maxMissedCycles * time.Duration(config.RefreshInterval) * time.Second
+	last := status.getLastSuccessfulCycle()
+	if last.IsZero() {
+		if time.Since(startedAt) > maxAge {
+			return errors.New("no successful cycle since start")
+		}
+		return nil
+	}
+	if age := time.Since(last); age > maxAge {
+		return fmt.Errorf("last successful cycle %s ago", age.Round(time.Second))
+	}
+	return nil
+}
+
+func writeHealthReport(w http.ResponseWriter, report healthReport) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	if report.Status != "ok" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Error("health", "writing health report: %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	appmodel "weather-app2/app/model"
+	"weather-app2/broker"
+	"weather-app2/eliona"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// Irradiance in W/m² is estimated from the clear-sky model of Haurwitz (1945), reduced by cloud cover
+// after Kasten and Czeplak (1980) and split into direct and diffuse parts after Erbs et al. (1982).
+// PV output follows from the irradiance on the tilted modules with an isotropic sky and a cell
+// temperature correction. These are estimates for comparison, not a replacement for a data logger.
+
+const (
+	solarConstant = 1361.0
+	groundAlbedo  = 0.2
+	// pvTemperatureCoefficient is the relative power change per K of cell temperature above 25 °C.
+	pvTemperatureCoefficient = -0.004
+	// nominalOperatingCellTemperature is the cell temperature at 800 W/m² and 20 °C air.
+	nominalOperatingCellTemperature = 45.0
+)
+
+type irradiance struct {
+	ghi, dni, dhi float64
+	clearSkyGHI   float64
+}
+
+// estimateIrradiance returns the irradiance at a location for the sun's elevation and cloud cover in %.
+func estimateIrradiance(t time.Time, elevation, clouds float64) irradiance {
+	cosZenith := sin(elevation)
+	if cosZenith <= 0 {
+		return irradiance{}
+	}
+	clearSky := 1098 * cosZenith * math.Exp(-0.057/cosZenith)
+	ghi := clearSky * (1 - 0.75*math.Pow(math.Min(math.Max(clouds, 0), 100)/100, 3.4))
+
+	extraterrestrial := solarConstant * (1 + 0.033*cos(360*float64(t.UTC().YearDay())/365))
+	if cosZenith < 0.05 {
+		// Close to the horizon the direct part cannot be told apart reliably.
+		return irradiance{ghi: ghi, dhi: ghi, clearSkyGHI: clearSky}
+	}
+	kt := math.Min(ghi/(extraterrestrial*cosZenith), 1)
+	var diffuseFraction float64
+	switch {
+	case kt <= 0.22:
+		diffuseFraction = 1 - 0.09*kt
+	case kt <= 0.8:
+		diffuseFraction = 0.9511 - 0.1604*kt + 4.388*kt*kt - 16.638*kt*kt*kt + 12.336*kt*kt*kt*kt
+	default:
+		diffuseFraction = 0.165
+	}
+	dhi := diffuseFraction * ghi
+	dni := math.Min((ghi-dhi)/cosZenith, extraterrestrial)
+	return irradiance{ghi: ghi, dni: dni, dhi: dhi, clearSkyGHI: clearSky}
+}
+
+// planeOfArray returns the irradiance on the modules of the array for the sun's position.
+func planeOfArray(irr irradiance, elevation, azimuth float64, pv appmodel.PVArray) float64 {
+	zenith := 90 - elevation
+	cosIncidence := sin(elevation)*cos(pv.Tilt) + sin(zenith)*sin(pv.Tilt)*cos(azimuth-pv.Azimuth)
+	beam := irr.dni * math.Max(cosIncidence, 0)
+	diffuse := irr.dhi * (1 + cos(pv.Tilt)) / 2
+	reflected := irr.ghi * groundAlbedo * (1 - cos(pv.Tilt)) / 2
+	return beam + diffuse + reflected
+}
+
+// pvPower returns the AC power in kW of the array for the irradiance on its modules and the air temperature.
+func pvPower(pv appmodel.PVArray, poa, temperature float64) float64 {
+	cellTemperature := temperature + poa*(nominalOperatingCellTemperature-20)/800
+	temperatureFactor := 1 + pvTemperatureCoefficient*(cellTemperature-25)
+	return math.Max(pv.PeakPower*poa/1000*temperatureFactor*(1-pv.Losses/100), 0)
+}
+
+// pvPowerAt estimates the power of the array at a location for the given time, cloud cover and temperature.
+func pvPowerAt(asset appmodel.Asset, pv appmodel.PVArray, t time.Time, clouds, temperature float64) float64 {
+	elevation, azimuth := solarPosition(t, asset.Lat, asset.Lon)
+	irr := estimateIrradiance(t, elevation, clouds)
+	return pvPower(pv, planeOfArray(irr, elevation, azimuth, pv), temperature)
+}
+
+// addIrradiance sets the estimated irradiance and, for locations with a PV array, its current and forecast
+// power in weatherMap.
+func addIrradiance(asset appmodel.Asset, pv *appmodel.PVArray, weather broker.WeatherData, weatherMap map[string]any) {
+	clouds, ok := toFloat(weatherMap["clouds"])
+	if !ok {
+		return
+	}
+	observedAt := time.Unix(weather.Current.Dt, 0)
+	elevation, _ := solarPosition(observedAt, asset.Lat, asset.Lon)
+	irr := estimateIrradiance(observedAt, elevation, clouds)
+	weatherMap["ghi"] = irr.ghi
+	weatherMap["dni"] = irr.dni
+	weatherMap["dhi"] = irr.dhi
+	weatherMap["clear_sky_ghi"] = irr.clearSkyGHI
+
+	if pv == nil {
+		return
+	}
+	if temperature, ok := toFloat(weatherMap["temperature"]); ok {
+		weatherMap["pv_power"] = pvPowerAt(asset, *pv, observedAt, clouds, temperature)
+	}
+	if len(weather.Hourly) == 0 {
+		return
+	}
+	for _, hours := range forecastHorizons {
+		forecast := closestForecast(weather.Hourly, observedAt.Add(time.Duration(hours)*time.Hour))
+		weatherMap[fmt.Sprintf("pv_power_forecast_%dh", hours)] = pvPowerAt(asset, *pv, time.Unix(forecast.Dt, 0), float64(forecast.Clouds), forecast.Temp)
+	}
+	// Each hourly forecast stands for the hour starting at its time; the power at half past is taken as its mean.
+	energy := 0.0
+	for _, h := range weather.Hourly {
+		start := time.Unix(h.Dt, 0)
+		if !start.Before(observedAt.Add(24 * time.Hour)) {
+			break
+		}
+		energy += pvPowerAt(asset, *pv, start.Add(30*time.Minute), float64(h.Clouds), h.Temp)
+	}
+	weatherMap["pv_energy_next_24h"] = energy
+}
+
+// pvArrays reads the PV array properties of all weather assets by Eliona asset ID. Assets without a peak
+// power have no array.
+func pvArrays() map[int32]appmodel.PVArray {
+	properties, err := eliona.GetAssetTypeData(eliona.WeatherAssetType, api.SUBTYPE_PROPERTY)
+	if err != nil {
+		log.Error("eliona", "getting PV array properties: %v", err)
+		return nil
+	}
+	arrays := make(map[int32]appmodel.PVArray)
+	for _, property := range properties {
+		peakPower, ok := propertyFloat(property.Data["pv_peak_power"])
+		if !ok || peakPower <= 0 {
+			continue
+		}
+		pv := appmodel.DefaultPVArray
+		pv.PeakPower = peakPower
+		if tilt, ok := propertyFloat(property.Data["pv_tilt"]); ok && tilt >= 0 && tilt <= 90 {
+			pv.Tilt = tilt
+		}
+		if azimuth, ok := propertyFloat(property.Data["pv_azimuth"]); ok && azimuth >= 0 && azimuth <= 360 {
+			pv.Azimuth = azimuth
+		}
+		if losses, ok := propertyFloat(property.Data["pv_losses"]); ok && losses >= 0 && losses < 100 {
+			pv.Losses = losses
+		}
+		arrays[property.AssetId] = pv
+	}
+	return arrays
+}
+
+// propertyFloat reads a number from asset properties, which are strings when entered in Eliona.
+func propertyFloat(v any) (float64, bool) {
+	if s, ok := v.(string); ok {
+		f, err := strconv.ParseFloat(s, 64)
+		return f, err == nil
+	}
+	return toFloat(v)
+}
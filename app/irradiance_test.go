@@ -0,0 +1,79 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"testing"
+	"time"
+	appmodel "weather-app2/app/model"
+)
+
+func TestEstimateIrradiance(t *testing.T) {
+	at := time.Date(2024, 6, 21, 11, 0, 0, 0, time.UTC)
+
+	clear := estimateIrradiance(at, 60, 0)
+	assertNear(t, "clear-sky GHI", clear.ghi, 891, 2)
+	assertNear(t, "GHI from components", clear.dni*sin(60)+clear.dhi, clear.ghi, 0.01)
+	if clear.dni < 700 || clear.dhi > 200 {
+		t.Errorf("clear sky should be mostly direct, got DNI %.0f and DHI %.0f", clear.dni, clear.dhi)
+	}
+
+	overcast := estimateIrradiance(at, 60, 100)
+	assertNear(t, "overcast GHI", overcast.ghi, clear.ghi/4, 1)
+	assertNear(t, "overcast clear-sky GHI", overcast.clearSkyGHI, clear.ghi, 0.01)
+	if overcast.dhi < 0.9*overcast.ghi {
+		t.Errorf("overcast sky should be mostly diffuse, got DNI %.0f and DHI %.0f", overcast.dni, overcast.dhi)
+	}
+
+	if night := estimateIrradiance(at, -5, 0); night != (irradiance{}) {
+		t.Errorf("irradiance at night %+v", night)
+	}
+}
+
+func TestPVPower(t *testing.T) {
+	pv := appmodel.PVArray{PeakPower: 10, Tilt: 30, Azimuth: 180, Losses: 14}
+	// Standard test conditions: 1000 W/m² and 25 °C cell temperature.
+	airTemperature := 25 - 1000*(nominalOperatingCellTemperature-20)/800
+	assertNear(t, "power at STC", pvPower(pv, 1000, airTemperature), 8.6, 0.001)
+	assertNear(t, "power in the dark", pvPower(pv, 0, 10), 0, 0)
+	if hot, cool := pvPower(pv, 800, 35), pvPower(pv, 800, 5); hot >= cool {
+		t.Errorf("hot modules (%.2f kW) should yield less than cool ones (%.2f kW)", hot, cool)
+	}
+}
+
+func TestPVPowerOrientation(t *testing.T) {
+	asset := appmodel.Asset{Lat: zurichLat, Lon: zurichLon}
+	noon := time.Date(2024, 3, 20, 11, 25, 0, 0, time.UTC)
+	south := pvPowerAt(asset, appmodel.PVArray{PeakPower: 10, Tilt: 30, Azimuth: 180}, noon, 0, 10)
+	north := pvPowerAt(asset, appmodel.PVArray{PeakPower: 10, Tilt: 30, Azimuth: 0}, noon, 0, 10)
+	if south <= north {
+		t.Errorf("south-facing array (%.2f kW) should yield more than north-facing (%.2f kW) at noon", south, north)
+	}
+	if night := pvPowerAt(asset, appmodel.PVArray{PeakPower: 10, Tilt: 30, Azimuth: 180}, noon.Add(12*time.Hour), 0, 10); night != 0 {
+		t.Errorf("power at midnight %.2f kW", night)
+	}
+}
+
+func TestPropertyFloat(t *testing.T) {
+	for _, v := range []any{"12.5", 12.5, float32(12.5)} {
+		if f, ok := propertyFloat(v); !ok || f != 12.5 {
+			t.Errorf("propertyFloat(%#v) = %v, %v", v, f, ok)
+		}
+	}
+	if _, ok := propertyFloat("south"); ok {
+		t.Error("text accepted as number")
+	}
+}
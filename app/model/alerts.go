@@ -0,0 +1,51 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package appmodel
+
+import "slices"
+
+// Alert notifies Eliona users when a weather attribute of a location crosses a threshold, e.g. for frost
+// warnings, and again once it is resolved.
+type Alert struct {
+	// Attribute is the weather attribute the alert watches.
+	Attribute string `json:"attribute"`
+	// Condition is RuleAbove or RuleBelow.
+	Condition string  `json:"condition"`
+	Threshold float64 `json:"threshold"`
+	// Hysteresis is how far the value has to come back past the threshold for the alert to be resolved.
+	Hysteresis float64 `json:"hysteresis,omitempty"`
+	// Cooldown is the seconds after a notification in which the alert doesn't notify about the same location again.
+	Cooldown int32 `json:"cooldown,omitempty"`
+	// Locations and ProjectIDs limit the alert to these locations and the locations of these projects.
+	// Both empty means all locations.
+	Locations  []int64  `json:"locations,omitempty"`
+	ProjectIDs []string `json:"projectIDs,omitempty"`
+	// Users are the Eliona users notified, by ID or e-mail. Empty means the user of the configuration.
+	Users []string `json:"users,omitempty"`
+}
+
+// AppliesTo reports whether the alert watches the location.
+func (a Alert) AppliesTo(asset Asset) bool {
+	if len(a.Locations) == 0 && len(a.ProjectIDs) == 0 {
+		return true
+	}
+	return slices.Contains(a.Locations, asset.ID) || slices.Contains(a.ProjectIDs, asset.ProjectID)
+}
+
+// Rule returns the threshold of the alert as a rule without hold times.
+func (a Alert) Rule() Rule {
+	return Rule{Attribute: a.Attribute, Condition: a.Condition, Threshold: a.Threshold, Hysteresis: a.Hysteresis}
+}
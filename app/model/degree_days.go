@@ -0,0 +1,54 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package appmodel
+
+// DegreeDayBase holds the temperatures in °C that degree days of a project are counted against.
+//
+// A day counts as heating day if its mean temperature is below HeatingLimit and adds HeatingBase minus the
+// mean. SIA 381/3 uses a base of 20 °C and a limit of 12 °C; classic degree days use the same value for both.
+// A day adds its mean minus CoolingBase to the cooling degree days if the mean is above CoolingBase.
+type DegreeDayBase struct {
+	HeatingBase  float64 `json:"heatingBase"`
+	HeatingLimit float64 `json:"heatingLimit"`
+	CoolingBase  float64 `json:"coolingBase"`
+}
+
+// DefaultDegreeDayBase applies to projects without own base temperatures.
+var DefaultDegreeDayBase = DegreeDayBase{HeatingBase: 20, HeatingLimit: 12, CoolingBase: 18}
+
+// DegreeDayBaseFor returns the base temperatures configured for the project or the default ones.
+func (c Configuration) DegreeDayBaseFor(projectID string) DegreeDayBase {
+	if base, ok := c.DegreeDayBases[projectID]; ok {
+		return base
+	}
+	return DefaultDegreeDayBase
+}
+
+// HeatingDegrees returns the heating degree days of a day with the given mean temperature.
+func (b DegreeDayBase) HeatingDegrees(mean float64) float64 {
+	if mean >= b.HeatingLimit {
+		return 0
+	}
+	return b.HeatingBase - mean
+}
+
+// CoolingDegrees returns the cooling degree days of a day with the given mean temperature.
+func (b DegreeDayBase) CoolingDegrees(mean float64) float64 {
+	if mean <= b.CoolingBase {
+		return 0
+	}
+	return mean - b.CoolingBase
+}
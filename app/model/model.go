@@ -15,6 +15,8 @@
 
 package appmodel
 
+import "time"
+
 type Configuration struct {
 	Id              int64
 	ApiKey          string
@@ -24,6 +26,36 @@ type Configuration struct {
 	Active          bool
 	ProjectIDs      []string
 	UserId          string
+
+	// StaleAfterIntervals is the number of refresh intervals a location may go without a successful update. 0 disables the check.
+	StaleAfterIntervals int32
+	// MaxDataAge is the age in seconds the provider's observation may reach. 0 disables the check.
+	MaxDataAge int32
+	// NotifyStale sends an Eliona notification to UserId when a location becomes stale.
+	NotifyStale bool
+	// PlausibilityLimits override the DefaultPlausibilityLimits by attribute name.
+	PlausibilityLimits map[string]PlausibilityLimit
+	// DegreeDayBases override the DefaultDegreeDayBase by project ID.
+	DegreeDayBases map[string]DegreeDayBase
+	// Rules switch output attributes of each location, by output attribute name.
+	Rules map[string]Rule
+	// Alerts notify users when weather attributes cross thresholds, by alert name.
+	Alerts map[string]Alert
+}
+
+// ConfigurationRevision is a recorded change of the configuration along with the resulting configuration.
+type ConfigurationRevision struct {
+	ID            int64
+	ChangedAt     time.Time
+	UserId        string
+	Diff          map[string]ConfigurationChange
+	Configuration Configuration
+}
+
+// ConfigurationChange holds the old and new value of a changed configuration field. Secrets are masked.
+type ConfigurationChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
 }
 
 type FilterRule struct {
@@ -40,6 +72,13 @@ type Asset struct {
 	AssetID      int32
 }
 
+// Observation is the weather data of a location at the time it was observed by the provider.
+type Observation struct {
+	AssetID    int64
+	ObservedAt time.Time
+	Data       map[string]float64
+}
+
 type RootAsset struct {
 	ID      int64
 	AssetID int32
@@ -0,0 +1,70 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package appmodel
+
+// PlausibilityLimit bounds the values accepted for a weather attribute. Unset fields are not checked.
+type PlausibilityLimit struct {
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+	// MaxChangePerHour is the largest plausible change between two observations, scaled to one hour.
+	MaxChangePerHour *float64 `json:"maxChangePerHour,omitempty"`
+}
+
+func limit(min, max float64, maxChangePerHour ...float64) PlausibilityLimit {
+	l := PlausibilityLimit{Min: &min, Max: &max}
+	if len(maxChangePerHour) > 0 {
+		l.MaxChangePerHour = &maxChangePerHour[0]
+	}
+	return l
+}
+
+// DefaultPlausibilityLimits are the limits applied to the weather attributes unless the configuration
+// overrides them. The ranges enclose the recorded extremes on earth.
+var DefaultPlausibilityLimits = map[string]PlausibilityLimit{
+	"temperature": limit(-90, 60, 10),
+	"feels_like":  limit(-100, 80, 15),
+	"dew_point":   limit(-90, 40, 10),
+	"pressure":    limit(870, 1085, 10),
+	"humidity":    limit(0, 100),
+	"uvi":         limit(0, 20),
+	"clouds":      limit(0, 100),
+	"wind_speed":  limit(0, 115),
+	"wind_deg":    limit(0, 360),
+	"wind_gust":   limit(0, 150),
+
+	"forecast_temperature_3h":    limit(-90, 60),
+	"forecast_temperature_6h":    limit(-90, 60),
+	"forecast_temperature_12h":   limit(-90, 60),
+	"forecast_temperature_24h":   limit(-90, 60),
+	"forecast_temperature_48h":   limit(-90, 60),
+	"forecast_precipitation_3h":  limit(0, 100),
+	"forecast_precipitation_6h":  limit(0, 100),
+	"forecast_precipitation_12h": limit(0, 100),
+	"forecast_precipitation_24h": limit(0, 100),
+	"forecast_precipitation_48h": limit(0, 100),
+}
+
+// PlausibilityLimitsWithDefaults returns the default limits with the overrides of the configuration applied.
+func (c Configuration) PlausibilityLimitsWithDefaults() map[string]PlausibilityLimit {
+	limits := make(map[string]PlausibilityLimit, len(DefaultPlausibilityLimits))
+	for attribute, l := range DefaultPlausibilityLimits {
+		limits[attribute] = l
+	}
+	for attribute, l := range c.PlausibilityLimits {
+		limits[attribute] = l
+	}
+	return limits
+}
@@ -0,0 +1,31 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package appmodel
+
+// PVArray describes a photovoltaic array at a location, as set in the properties of the weather asset.
+type PVArray struct {
+	// PeakPower is the nominal power in kWp.
+	PeakPower float64
+	// Tilt is the angle of the modules from horizontal in degrees.
+	Tilt float64
+	// Azimuth is the direction the modules face, clockwise from north in degrees.
+	Azimuth float64
+	// Losses are the system losses (inverter, wiring, soiling) in percent.
+	Losses float64
+}
+
+// DefaultPVArray holds the values used for properties that are not set. The losses follow PVWatts.
+var DefaultPVArray = PVArray{Tilt: 30, Azimuth: 180, Losses: 14}
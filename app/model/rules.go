@@ -0,0 +1,41 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package appmodel
+
+// Conditions of a Rule.
+const (
+	RuleAbove = "above"
+	RuleBelow = "below"
+)
+
+// SolarAttributes are the attributes of the sun's position and daylight. They are written separately from
+// the weather, which is where rules and alerts are evaluated, so neither can watch them.
+var SolarAttributes = []string{"solar_elevation", "solar_azimuth", "daylight", "day_length", "minutes_to_sunset"}
+
+// Rule switches a boolean output attribute of each location depending on a weather attribute, e.g. to retract
+// blinds if the wind gusts are above 15 m/s.
+type Rule struct {
+	// Attribute is the weather attribute the rule watches.
+	Attribute string `json:"attribute"`
+	// Condition is RuleAbove or RuleBelow.
+	Condition string  `json:"condition"`
+	Threshold float64 `json:"threshold"`
+	// Hysteresis is how far the value has to come back past the threshold to switch the output off again.
+	Hysteresis float64 `json:"hysteresis,omitempty"`
+	// MinOnTime and MinOffTime are the seconds the output keeps its state at least after switching.
+	MinOnTime  int32 `json:"minOnTime,omitempty"`
+	MinOffTime int32 `json:"minOffTime,omitempty"`
+}
@@ -0,0 +1,66 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"fmt"
+	"math"
+	"time"
+	"weather-app2/broker"
+)
+
+// rainThreshold is the precipitation intensity in mm/h from which a minute counts as rainy. Below it the
+// nowcast shows traces that do not wet surfaces.
+const rainThreshold = 0.1
+
+// nowcastHorizons are the minutes ahead for which a rain warning is provided as attribute.
+var nowcastHorizons = []int{10, 30, 60}
+
+// noRainMinutes is reported as minutes to rain if no rain is expected. It is beyond the nowcast hour, so
+// "less than" rules do not fire, however many minutes the provider returned.
+const noRainMinutes = 61
+
+// addNowcast sets the rain warnings from the provider's minutely precipitation nowcast in weatherMap.
+// Without a nowcast for the location nothing is set.
+func addNowcast(weather broker.WeatherData, weatherMap map[string]any) {
+	if len(weather.Minutely) == 0 {
+		return
+	}
+	now := time.Unix(weather.Current.Dt, 0)
+	rainExpected := false
+	minutesToRain := noRainMinutes
+	total := 0.0
+	for _, m := range weather.Minutely {
+		minutes := int(math.Max(0, math.Round(time.Unix(m.Dt, 0).Sub(now).Minutes())))
+		if m.Precipitation >= rainThreshold && (!rainExpected || minutes < minutesToRain) {
+			rainExpected = true
+			minutesToRain = minutes
+		}
+		// Each entry is the intensity during one minute.
+		total += m.Precipitation / 60
+	}
+
+	weatherMap["precipitation_intensity"] = weather.Minutely[0].Precipitation
+	weatherMap["minutes_to_rain"] = minutesToRain
+	weatherMap["precipitation_next_hour"] = total
+	for _, horizon := range nowcastHorizons {
+		expected := 0
+		if rainExpected && minutesToRain <= horizon {
+			expected = 1
+		}
+		weatherMap[fmt.Sprintf("rain_expected_%dmin", horizon)] = expected
+	}
+}
@@ -0,0 +1,94 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"weather-app2/broker"
+)
+
+func nowcast(now time.Time, intensities ...float64) broker.WeatherData {
+	weather := broker.WeatherData{Current: broker.CurrentWeather{Dt: now.Unix()}}
+	for i, intensity := range intensities {
+		weather.Minutely = append(weather.Minutely, broker.MinutelyWeather{
+			Dt:            now.Add(time.Duration(i) * time.Minute).Unix(),
+			Precipitation: intensity,
+		})
+	}
+	return weather
+}
+
+func TestAddNowcast(t *testing.T) {
+	now := time.Date(2024, 7, 1, 14, 0, 0, 0, time.UTC)
+	intensities := make([]float64, 61)
+	for i := 20; i < 50; i++ {
+		intensities[i] = 6
+	}
+	intensities[5] = 0.05 // A trace below the threshold.
+
+	weatherMap := map[string]any{}
+	addNowcast(nowcast(now, intensities...), weatherMap)
+
+	if weatherMap["minutes_to_rain"] != 20 {
+		t.Errorf("minutes to rain %v, want 20", weatherMap["minutes_to_rain"])
+	}
+	if weatherMap["rain_expected_10min"] != 0 || weatherMap["rain_expected_30min"] != 1 || weatherMap["rain_expected_60min"] != 1 {
+		t.Errorf("rain expected 10/30/60 min: %v/%v/%v, want 0/1/1",
+			weatherMap["rain_expected_10min"], weatherMap["rain_expected_30min"], weatherMap["rain_expected_60min"])
+	}
+	assertNear(t, "precipitation next hour", weatherMap["precipitation_next_hour"].(float64), 3.0+0.05/60, 1e-9)
+	assertNear(t, "precipitation intensity", weatherMap["precipitation_intensity"].(float64), 0, 0)
+}
+
+func TestAddNowcastRainingNow(t *testing.T) {
+	weatherMap := map[string]any{}
+	addNowcast(nowcast(time.Now(), 1.2, 1.0, 0.8), weatherMap)
+	if weatherMap["minutes_to_rain"] != 0 || weatherMap["rain_expected_10min"] != 1 {
+		t.Errorf("minutes to rain %v and rain expected %v while raining", weatherMap["minutes_to_rain"], weatherMap["rain_expected_10min"])
+	}
+}
+
+func TestAddNowcastDry(t *testing.T) {
+	weatherMap := map[string]any{}
+	addNowcast(nowcast(time.Now(), make([]float64, 61)...), weatherMap)
+	if weatherMap["minutes_to_rain"] != 61 || weatherMap["rain_expected_60min"] != 0 {
+		t.Errorf("minutes to rain %v and rain expected %v without rain", weatherMap["minutes_to_rain"], weatherMap["rain_expected_60min"])
+	}
+
+	// A shorter nowcast, e.g. when the provider drops minutes, is dry at every horizon.
+	for _, minutes := range []int{60, 25} {
+		weatherMap = map[string]any{}
+		addNowcast(nowcast(time.Now(), make([]float64, minutes)...), weatherMap)
+		if weatherMap["minutes_to_rain"] != noRainMinutes {
+			t.Errorf("minutes to rain %v without rain in %d minutes, want %d", weatherMap["minutes_to_rain"], minutes, noRainMinutes)
+		}
+		for _, horizon := range nowcastHorizons {
+			attribute := fmt.Sprintf("rain_expected_%dmin", horizon)
+			if weatherMap[attribute] != 0 {
+				t.Errorf("%s = %v without rain in %d minutes", attribute, weatherMap[attribute], minutes)
+			}
+		}
+	}
+
+	// Locations without a nowcast get no attributes.
+	weatherMap = map[string]any{}
+	addNowcast(broker.WeatherData{}, weatherMap)
+	if len(weatherMap) != 0 {
+		t.Errorf("attributes without nowcast: %v", weatherMap)
+	}
+}
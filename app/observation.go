@@ -0,0 +1,78 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	"strconv"
+	"time"
+	appmodel "weather-app2/app/model"
+	dbhelper "weather-app2/db/helper"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// observationRetention returns how long observations are kept, configured in days by
+// OBSERVATION_RETENTION_DAYS. The default of 31 days covers the monthly degree days; 0 keeps them forever.
+func observationRetention() time.Duration {
+	days, err := strconv.Atoi(common.Getenv("OBSERVATION_RETENTION_DAYS", "31"))
+	if err != nil {
+		log.Warn("app", "Invalid OBSERVATION_RETENTION_DAYS, using 31 days: %v", err)
+		days = 31
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// checkObservationRetention warns if the observations are deleted before the monthly degree days are
+// complete, which need the days of the month so far.
+func checkObservationRetention() {
+	if retention := observationRetention(); retention > 0 && retention < minDegreeDayRetention {
+		log.Warn("app", "OBSERVATION_RETENTION_DAYS is shorter than %d days, so monthly degree days will miss the first days of long months", int(minDegreeDayRetention.Hours()/24))
+	}
+}
+
+// storeObservation keeps the numeric weather values written to Eliona in the local history.
+func storeObservation(asset appmodel.Asset, observedAt time.Time, weatherMap map[string]any) {
+	data := make(map[string]float64, len(weatherMap))
+	for attribute, v := range weatherMap {
+		if value, ok := toFloat(v); ok {
+			data[attribute] = value
+		}
+	}
+	if err := dbhelper.InsertObservation(context.Background(), appmodel.Observation{
+		AssetID:    asset.ID,
+		ObservedAt: observedAt,
+		Data:       data,
+	}); err != nil {
+		log.Error("dbhelper", "storing observation of asset %v: %v", asset.AssetID, err)
+	}
+}
+
+func deleteExpiredObservations(ctx context.Context) {
+	retention := observationRetention()
+	if retention <= 0 {
+		return
+	}
+	deleted, err := dbhelper.DeleteObservationsBefore(ctx, time.Now().Add(-retention))
+	if err != nil {
+		log.Error("dbhelper", "deleting expired observations: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Debug("dbhelper", "Deleted %d expired observations.", deleted)
+	}
+}
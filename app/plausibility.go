@@ -0,0 +1,152 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+	appmodel "weather-app2/app/model"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// minChangeInterval is the shortest time a rate of change is scaled from. The provider updates its
+// observations about every ten minutes, so shorter gaps would exaggerate the rate.
+const minChangeInterval = 10 * time.Minute
+
+// acceptedValue is the last value of an attribute that passed the checks.
+type acceptedValue struct {
+	value      float64
+	observedAt time.Time
+}
+
+// plausibilityChecker holds back implausible weather values. It remembers the last accepted value
+// of each attribute to check the rate of change.
+type plausibilityChecker struct {
+	mu       sync.Mutex
+	accepted map[int32]map[string]acceptedValue
+	quality  map[int32]int
+}
+
+var plausibility = plausibilityChecker{
+	accepted: make(map[int32]map[string]acceptedValue),
+	quality:  make(map[int32]int),
+}
+
+// Values of the data_quality attribute of the weather asset.
+const (
+	qualityOK = iota
+	qualityImplausible
+)
+
+// filter removes the values from weatherMap that violate the limits and returns the rejected attributes.
+func (c *plausibilityChecker) filter(asset appmodel.Asset, weatherMap map[string]any, observedAt time.Time, limits map[string]appmodel.PlausibilityLimit) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	accepted, ok := c.accepted[asset.AssetID]
+	if !ok {
+		accepted = make(map[string]acceptedValue)
+		c.accepted[asset.AssetID] = accepted
+	}
+
+	var rejected []string
+	for attribute, v := range weatherMap {
+		value, ok := toFloat(v)
+		if !ok {
+			continue
+		}
+		limit, ok := limits[attribute]
+		if !ok {
+			continue
+		}
+		if reason := implausibility(value, limit, accepted[attribute], observedAt); reason != "" {
+			log.Warn("app", "Holding back %s = %v of location %s: %s", attribute, value, asset.LocationName, reason)
+			delete(weatherMap, attribute)
+			rejected = append(rejected, attribute)
+			continue
+		}
+		accepted[attribute] = acceptedValue{value: value, observedAt: observedAt}
+	}
+	return rejected
+}
+
+// qualityChanged records the data quality of the asset and tells whether it differs from the last one recorded.
+func (c *plausibilityChecker) qualityChanged(assetID int32, quality int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	previous, ok := c.quality[assetID]
+	c.quality[assetID] = quality
+	return !ok || previous != quality
+}
+
+// prune drops the state of locations that were deleted.
+func (c *plausibilityChecker) prune(assets []appmodel.Asset) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	present := make(map[int32]bool, len(assets))
+	for _, asset := range assets {
+		present[asset.AssetID] = true
+	}
+	for assetID := range c.accepted {
+		if !present[assetID] {
+			delete(c.accepted, assetID)
+		}
+	}
+	for assetID := range c.quality {
+		if !present[assetID] {
+			delete(c.quality, assetID)
+		}
+	}
+}
+
+func implausibility(value float64, limit appmodel.PlausibilityLimit, previous acceptedValue, observedAt time.Time) string {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return "not a number"
+	}
+	if limit.Min != nil && value < *limit.Min {
+		return fmt.Sprintf("below minimum %v", *limit.Min)
+	}
+	if limit.Max != nil && value > *limit.Max {
+		return fmt.Sprintf("above maximum %v", *limit.Max)
+	}
+	if limit.MaxChangePerHour != nil && !previous.observedAt.IsZero() {
+		elapsed := max(observedAt.Sub(previous.observedAt), minChangeInterval)
+		change := math.Abs(value-previous.value) / elapsed.Hours()
+		if change > *limit.MaxChangePerHour {
+			return fmt.Sprintf("changed by %.1f per hour since %v, more than %v", change, previous.value, *limit.MaxChangePerHour)
+		}
+	}
+	return ""
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
@@ -0,0 +1,48 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"testing"
+	"time"
+	appmodel "weather-app2/app/model"
+)
+
+func TestPlausibilityPrune(t *testing.T) {
+	checker := plausibilityChecker{
+		accepted: make(map[int32]map[string]acceptedValue),
+		quality:  make(map[int32]int),
+	}
+	limits := map[string]appmodel.PlausibilityLimit{"temperature": {}}
+	for _, asset := range []appmodel.Asset{{AssetID: 100}, {AssetID: 200}} {
+		checker.filter(asset, map[string]any{"temperature": 12.0}, time.Now(), limits)
+		checker.qualityChanged(asset.AssetID, qualityOK)
+	}
+
+	checker.prune([]appmodel.Asset{{AssetID: 200}})
+	if _, ok := checker.accepted[100]; ok {
+		t.Error("accepted values of deleted location kept")
+	}
+	if _, ok := checker.quality[100]; ok {
+		t.Error("data quality of deleted location kept")
+	}
+	if _, ok := checker.accepted[200]; !ok {
+		t.Error("accepted values of existing location dropped")
+	}
+	if checker.qualityChanged(200, qualityOK) {
+		t.Error("data quality of existing location forgotten")
+	}
+}
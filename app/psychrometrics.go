@@ -0,0 +1,97 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import "math"
+
+// Psychrometric properties of moist air from temperature in °C, relative humidity in % and air pressure
+// in hPa. The formulas follow the ASHRAE Handbook Fundamentals (2017), chapter 1, with saturation vapor
+// pressure over water after Alduchov and Eskridge (1996). The provider reports sea-level pressure, so
+// values are for sea level; at 500 m the mixing ratio is about 6 % higher.
+
+const (
+	// molarMassRatio is the ratio of the molar masses of water vapor and dry air.
+	molarMassRatio = 0.621945
+	// gasConstantDryAir and gasConstantVapor are the specific gas constants in J/(kg·K).
+	gasConstantDryAir = 287.042
+	gasConstantVapor  = 461.524
+)
+
+// saturationVaporPressure returns the saturation vapor pressure over water in hPa.
+func saturationVaporPressure(temperature float64) float64 {
+	return 6.1094 * math.Exp(17.625*temperature/(temperature+243.04))
+}
+
+// vaporPressure returns the partial pressure of water vapor in hPa.
+func vaporPressure(temperature, humidity float64) float64 {
+	return humidity / 100 * saturationVaporPressure(temperature)
+}
+
+// mixingRatio returns the mass of water vapor per mass of dry air in kg/kg.
+func mixingRatio(temperature, humidity, pressure float64) float64 {
+	e := vaporPressure(temperature, humidity)
+	return molarMassRatio * e / (pressure - e)
+}
+
+// enthalpy returns the specific enthalpy of moist air in kJ per kg of dry air.
+func enthalpy(temperature, humidity, pressure float64) float64 {
+	w := mixingRatio(temperature, humidity, pressure)
+	return 1.006*temperature + w*(2501+1.86*temperature)
+}
+
+// airDensity returns the density of moist air in kg/m³.
+func airDensity(temperature, humidity, pressure float64) float64 {
+	e := vaporPressure(temperature, humidity)
+	kelvin := temperature + 273.15
+	return (pressure-e)*100/(gasConstantDryAir*kelvin) + e*100/(gasConstantVapor*kelvin)
+}
+
+// wetBulbTemperature returns the thermodynamic wet-bulb temperature in °C. It solves the ASHRAE
+// psychrometric equation for the wet-bulb temperature whose saturated air, cooled adiabatically,
+// gives the actual mixing ratio. The result lies between the dew point and the dry-bulb temperature.
+func wetBulbTemperature(temperature, humidity, pressure float64) float64 {
+	w := mixingRatio(temperature, humidity, pressure)
+	// mixingRatioAt is the mixing ratio that a wet-bulb temperature implies. It rises with the wet-bulb temperature.
+	mixingRatioAt := func(wetBulb float64) float64 {
+		saturated := molarMassRatio * saturationVaporPressure(wetBulb) / (pressure - saturationVaporPressure(wetBulb))
+		return ((2501-2.326*wetBulb)*saturated - 1.006*(temperature-wetBulb)) / (2501 + 1.86*temperature - 4.186*wetBulb)
+	}
+	low, high := temperature-60, temperature
+	for range 60 {
+		mid := (low + high) / 2
+		if mixingRatioAt(mid) > w {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+	return (low + high) / 2
+}
+
+// addPsychrometrics sets the psychrometric attributes in weatherMap if temperature, humidity and pressure
+// passed the plausibility check.
+func addPsychrometrics(weatherMap map[string]any) {
+	temperature, okT := toFloat(weatherMap["temperature"])
+	humidity, okH := toFloat(weatherMap["humidity"])
+	pressure, okP := toFloat(weatherMap["pressure"])
+	if !okT || !okH || !okP || pressure <= 0 {
+		return
+	}
+	weatherMap["enthalpy"] = enthalpy(temperature, humidity, pressure)
+	weatherMap["absolute_humidity"] = mixingRatio(temperature, humidity, pressure) * 1000
+	weatherMap["wet_bulb_temperature"] = wetBulbTemperature(temperature, humidity, pressure)
+	weatherMap["air_density"] = airDensity(temperature, humidity, pressure)
+}
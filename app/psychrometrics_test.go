@@ -0,0 +1,93 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"math"
+	"testing"
+)
+
+// Reference values from the ASHRAE psychrometric chart and tables at standard sea-level pressure.
+func TestPsychrometrics(t *testing.T) {
+	tests := []struct {
+		name        string
+		temperature float64
+		humidity    float64
+		pressure    float64
+
+		mixingRatio float64 // g/kg
+		enthalpy    float64 // kJ/kg
+		wetBulb     float64 // °C
+		density     float64 // kg/m³
+	}{
+		{name: "office air", temperature: 20, humidity: 50, pressure: 1013.25, mixingRatio: 7.26, enthalpy: 38.5, wetBulb: 13.7, density: 1.199},
+		{name: "hot and humid", temperature: 35, humidity: 40, pressure: 1013.25, mixingRatio: 14.1, enthalpy: 71.4, wetBulb: 24.0, density: 1.137},
+		{name: "saturated", temperature: 10, humidity: 100, pressure: 1013.25, mixingRatio: 7.66, enthalpy: 29.3, wetBulb: 10.0, density: 1.242},
+		{name: "freezing", temperature: -5, humidity: 80, pressure: 1013.25, mixingRatio: 2.09, enthalpy: 0.2, wetBulb: -5.9, density: 1.316},
+		{name: "dry air", temperature: 25, humidity: 0, pressure: 1013.25, mixingRatio: 0, enthalpy: 25.15, wetBulb: 8.3, density: 1.184},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertNear(t, "mixing ratio", mixingRatio(tt.temperature, tt.humidity, tt.pressure)*1000, tt.mixingRatio, 0.05)
+			assertNear(t, "enthalpy", enthalpy(tt.temperature, tt.humidity, tt.pressure), tt.enthalpy, 0.3)
+			assertNear(t, "wet-bulb temperature", wetBulbTemperature(tt.temperature, tt.humidity, tt.pressure), tt.wetBulb, 0.2)
+			assertNear(t, "air density", airDensity(tt.temperature, tt.humidity, tt.pressure), tt.density, 0.002)
+		})
+	}
+}
+
+func TestWetBulbTemperatureNotAboveDryBulb(t *testing.T) {
+	for _, humidity := range []float64{5, 30, 60, 90, 100} {
+		for _, temperature := range []float64{-20, 0, 15, 30, 45} {
+			wetBulb := wetBulbTemperature(temperature, humidity, 1013.25)
+			if wetBulb > temperature+1e-6 {
+				t.Errorf("wet-bulb %.2f °C above dry-bulb %.0f °C at %.0f %%", wetBulb, temperature, humidity)
+			}
+		}
+	}
+}
+
+func TestLowerPressureRaisesMixingRatio(t *testing.T) {
+	sea := mixingRatio(20, 50, 1013.25)
+	mountain := mixingRatio(20, 50, 850)
+	if mountain <= sea {
+		t.Errorf("mixing ratio at 850 hPa (%.5f) not above sea level (%.5f)", mountain, sea)
+	}
+}
+
+func TestAddPsychrometrics(t *testing.T) {
+	weatherMap := map[string]any{"temperature": 20.0, "humidity": 50, "pressure": 1013}
+	addPsychrometrics(weatherMap)
+	for _, attribute := range []string{"enthalpy", "absolute_humidity", "wet_bulb_temperature", "air_density"} {
+		if _, ok := weatherMap[attribute]; !ok {
+			t.Errorf("attribute %s missing", attribute)
+		}
+	}
+
+	// Without humidity, e.g. after the plausibility check held it back, nothing is derived.
+	weatherMap = map[string]any{"temperature": 20.0, "pressure": 1013}
+	addPsychrometrics(weatherMap)
+	if _, ok := weatherMap["enthalpy"]; ok {
+		t.Error("enthalpy derived without humidity")
+	}
+}
+
+func assertNear(t *testing.T, name string, got, want, tolerance float64) {
+	t.Helper()
+	if math.Abs(got-want) > tolerance {
+		t.Errorf("%s = %.4f, want %.4f ± %v", name, got, want, tolerance)
+	}
+}
@@ -0,0 +1,192 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"slices"
+	"sync"
+	"time"
+	appmodel "weather-app2/app/model"
+	"weather-app2/eliona"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+type ruleState struct {
+	on    bool
+	since time.Time
+}
+
+// ruleEvaluator keeps the output state of the rules per location, which hysteresis and hold times depend on.
+// After a restart the state starts from the current values, and the hold times count as elapsed.
+type ruleEvaluator struct {
+	mu     sync.Mutex
+	states map[int32]map[string]ruleState
+	// attributes are the enabled output attributes of the weather asset type, nil until read from Eliona.
+	attributes map[string]bool
+}
+
+var ruleOutputs = &ruleEvaluator{states: make(map[int32]map[string]ruleState)}
+
+// syncAttributes adds the output attributes of new rules to the weather asset type. The outputs of removed
+// rules are switched off at all locations and their attributes disabled, so that no automation acts on
+// their last value.
+func (e *ruleEvaluator) syncAttributes(rules map[string]appmodel.Rule, assets []appmodel.Asset, now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.attributes == nil {
+		outputs, err := eliona.GetOutputAttributes()
+		if err != nil {
+			log.Error("eliona", "getting output attributes: %v", err)
+			return
+		}
+		e.attributes = make(map[string]bool, len(outputs))
+		for _, output := range outputs {
+			e.attributes[output] = true
+		}
+	}
+	for output := range rules {
+		if e.attributes[output] {
+			continue
+		}
+		if err := eliona.UpsertOutputAttribute(output); err != nil {
+			log.Error("eliona", "adding output attribute %s: %v", output, err)
+			continue
+		}
+		e.attributes[output] = true
+	}
+
+	removed := removedOutputs(e.attributes, rules)
+	if len(removed) == 0 {
+		return
+	}
+	off := make(map[string]any, len(removed))
+	for _, output := range removed {
+		off[output] = 0
+	}
+	data := make(map[int32]map[string]any, len(assets))
+	for _, asset := range assets {
+		data[asset.AssetID] = off
+	}
+	if len(data) > 0 {
+		if err := eliona.UpsertDataBulk(data, now, api.SUBTYPE_OUTPUT); err != nil {
+			log.Error("eliona", "switching off outputs of removed rules %v: %v", removed, err)
+			return
+		}
+	}
+	for _, output := range removed {
+		if err := eliona.DisableOutputAttribute(output); err != nil {
+			log.Error("eliona", "disabling output attribute %s: %v", output, err)
+			continue
+		}
+		delete(e.attributes, output)
+	}
+}
+
+// removedOutputs returns the output attributes without a rule, sorted.
+func removedOutputs(attributes map[string]bool, rules map[string]appmodel.Rule) []string {
+	var removed []string
+	for output := range attributes {
+		if _, ok := rules[output]; !ok {
+			removed = append(removed, output)
+		}
+	}
+	slices.Sort(removed)
+	return removed
+}
+
+// prune drops the state of locations that were deleted.
+func (e *ruleEvaluator) prune(assets []appmodel.Asset) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	present := make(map[int32]bool, len(assets))
+	for _, asset := range assets {
+		present[asset.AssetID] = true
+	}
+	for assetID := range e.states {
+		if !present[assetID] {
+			delete(e.states, assetID)
+		}
+	}
+}
+
+// evaluate returns the outputs of the rules for a location as 0 or 1. Rules whose attribute is missing in
+// weatherMap, e.g. held back as implausible, keep their output.
+func (e *ruleEvaluator) evaluate(assetID int32, weatherMap map[string]any, now time.Time, rules map[string]appmodel.Rule) map[string]any {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	states, ok := e.states[assetID]
+	if !ok {
+		states = make(map[string]ruleState)
+		e.states[assetID] = states
+	}
+	for output := range states {
+		if _, ok := rules[output]; !ok {
+			delete(states, output)
+		}
+	}
+
+	outputs := make(map[string]any, len(rules))
+	for output, rule := range rules {
+		state, known := states[output]
+		if value, ok := toFloat(weatherMap[rule.Attribute]); ok {
+			state = nextRuleState(rule, state, known, value, now)
+			states[output] = state
+		} else if !known {
+			continue
+		}
+		outputs[output] = 0
+		if state.on {
+			outputs[output] = 1
+		}
+	}
+	return outputs
+}
+
+// nextRuleState switches the output on once the value crosses the threshold and off once it has come back
+// past the threshold by the hysteresis, but not before the minimum on or off time has passed.
+func nextRuleState(rule appmodel.Rule, state ruleState, known bool, value float64, now time.Time) ruleState {
+	if !known {
+		on := value > rule.Threshold
+		if rule.Condition == appmodel.RuleBelow {
+			on = value < rule.Threshold
+		}
+		return ruleState{on: on}
+	}
+
+	hold := time.Duration(rule.MinOffTime) * time.Second
+	if state.on {
+		hold = time.Duration(rule.MinOnTime) * time.Second
+	}
+	if now.Sub(state.since) < hold {
+		return state
+	}
+
+	var switchOn, switchOff bool
+	switch rule.Condition {
+	case appmodel.RuleAbove:
+		switchOn = value > rule.Threshold
+		switchOff = value < rule.Threshold-rule.Hysteresis
+	case appmodel.RuleBelow:
+		switchOn = value < rule.Threshold
+		switchOff = value > rule.Threshold+rule.Hysteresis
+	}
+	if !state.on && switchOn || state.on && switchOff {
+		return ruleState{on: !state.on, since: now}
+	}
+	return state
+}
@@ -0,0 +1,120 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"slices"
+	"testing"
+	"time"
+	appmodel "weather-app2/app/model"
+)
+
+func TestRuleHysteresis(t *testing.T) {
+	blinds := appmodel.Rule{Attribute: "wind_gust", Condition: appmodel.RuleAbove, Threshold: 15, Hysteresis: 3}
+	evaluator := &ruleEvaluator{states: make(map[int32]map[string]ruleState)}
+	start := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		gust float64
+		want int
+	}{
+		{gust: 10, want: 0},
+		{gust: 15, want: 0}, // Only values above the threshold switch on.
+		{gust: 16, want: 1},
+		{gust: 13, want: 1}, // Within the hysteresis.
+		{gust: 11.9, want: 0},
+		{gust: 14, want: 0},
+	}
+	for i, step := range steps {
+		outputs := evaluator.evaluate(1, map[string]any{"wind_gust": step.gust}, start.Add(time.Duration(i)*time.Minute), map[string]appmodel.Rule{"retract_blinds": blinds})
+		if outputs["retract_blinds"] != step.want {
+			t.Errorf("step %d: gust %.1f m/s gives %v, want %d", i, step.gust, outputs["retract_blinds"], step.want)
+		}
+	}
+}
+
+func TestRuleHoldTimes(t *testing.T) {
+	frost := appmodel.Rule{Attribute: "temperature", Condition: appmodel.RuleBelow, Threshold: 3, MinOnTime: 1800, MinOffTime: 600}
+	rules := map[string]appmodel.Rule{"frost_protection": frost}
+	evaluator := &ruleEvaluator{states: make(map[int32]map[string]ruleState)}
+	start := time.Date(2024, 1, 10, 6, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		after       time.Duration
+		temperature float64
+		want        int
+	}{
+		{after: 0, temperature: 5, want: 0},
+		{after: 5 * time.Minute, temperature: 2, want: 1},  // Hold times count as elapsed after a restart.
+		{after: 20 * time.Minute, temperature: 6, want: 1}, // Held on for 30 minutes.
+		{after: 36 * time.Minute, temperature: 6, want: 0},
+		{after: 40 * time.Minute, temperature: 1, want: 0}, // Held off for 10 minutes.
+		{after: 47 * time.Minute, temperature: 1, want: 1},
+	}
+	for i, step := range steps {
+		outputs := evaluator.evaluate(1, map[string]any{"temperature": step.temperature}, start.Add(step.after), rules)
+		if outputs["frost_protection"] != step.want {
+			t.Errorf("step %d: %.0f °C after %v gives %v, want %d", i, step.temperature, step.after, outputs["frost_protection"], step.want)
+		}
+	}
+}
+
+func TestRuleMissingValue(t *testing.T) {
+	rules := map[string]appmodel.Rule{"close_windows": {Attribute: "rain_expected_30min", Condition: appmodel.RuleAbove, Threshold: 0.5}}
+	evaluator := &ruleEvaluator{states: make(map[int32]map[string]ruleState)}
+	now := time.Now()
+
+	if outputs := evaluator.evaluate(1, map[string]any{}, now, rules); len(outputs) != 0 {
+		t.Errorf("outputs without any value: %v", outputs)
+	}
+	evaluator.evaluate(1, map[string]any{"rain_expected_30min": 1}, now, rules)
+	if outputs := evaluator.evaluate(1, map[string]any{}, now.Add(time.Minute), rules); outputs["close_windows"] != 1 {
+		t.Errorf("output %v after a missing value, want it kept at 1", outputs["close_windows"])
+	}
+
+	// Removed rules are forgotten.
+	evaluator.evaluate(1, map[string]any{}, now, nil)
+	if len(evaluator.states[1]) != 0 {
+		t.Errorf("state of removed rule kept: %v", evaluator.states[1])
+	}
+}
+
+func TestRulePrune(t *testing.T) {
+	rules := map[string]appmodel.Rule{"retract_blinds": {Attribute: "wind_speed", Condition: appmodel.RuleAbove, Threshold: 14}}
+	evaluator := &ruleEvaluator{states: make(map[int32]map[string]ruleState)}
+	now := time.Now()
+	evaluator.evaluate(1, map[string]any{"wind_speed": 20.0}, now, rules)
+	evaluator.evaluate(2, map[string]any{"wind_speed": 20.0}, now, rules)
+
+	evaluator.prune([]appmodel.Asset{{AssetID: 2}})
+	if _, ok := evaluator.states[1]; ok {
+		t.Error("state of deleted location kept")
+	}
+	if _, ok := evaluator.states[2]; !ok {
+		t.Error("state of existing location dropped")
+	}
+}
+
+func TestRemovedOutputs(t *testing.T) {
+	attributes := map[string]bool{"retract_blinds": true, "close_windows": true, "heat_tracing": true}
+	rules := map[string]appmodel.Rule{"retract_blinds": {}}
+	if removed := removedOutputs(attributes, rules); !slices.Equal(removed, []string{"close_windows", "heat_tracing"}) {
+		t.Errorf("removed outputs %v", removed)
+	}
+	if removed := removedOutputs(attributes, map[string]appmodel.Rule{"retract_blinds": {}, "close_windows": {}, "heat_tracing": {}}); len(removed) != 0 {
+		t.Errorf("removed outputs %v with all rules present", removed)
+	}
+}
@@ -0,0 +1,243 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+	appmodel "weather-app2/app/model"
+	dbhelper "weather-app2/db/helper"
+	"weather-app2/eliona"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// The sun's position is computed offline with the NOAA solar calculator algorithm, based on Meeus,
+// Astronomical Algorithms. It is accurate to about a minute for sunrise and sunset between ±72° latitude.
+
+// sunriseZenith is the zenith of the sun's center at sunrise and sunset, including refraction and the
+// radius of the sun's disc.
+const sunriseZenith = 90.833
+
+// sunDay holds sunrise and sunset of a day. In polar day or polar night there are neither, and one of
+// alwaysUp and alwaysDown is set.
+type sunDay struct {
+	sunrise    time.Time
+	sunset     time.Time
+	alwaysUp   bool
+	alwaysDown bool
+}
+
+func (d sunDay) dayLength() time.Duration {
+	switch {
+	case d.alwaysUp:
+		return 24 * time.Hour
+	case d.alwaysDown:
+		return 0
+	}
+	return d.sunset.Sub(d.sunrise)
+}
+
+func (d sunDay) isDaylight(t time.Time) bool {
+	switch {
+	case d.alwaysUp:
+		return true
+	case d.alwaysDown:
+		return false
+	}
+	return !t.Before(d.sunrise) && t.Before(d.sunset)
+}
+
+// sunCoordinates returns the sun's declination in degrees and the equation of time in minutes.
+func sunCoordinates(t time.Time) (declination, equationOfTime float64) {
+	julianDay := float64(t.UnixMilli())/86400000 + 2440587.5
+	c := (julianDay - 2451545) / 36525
+
+	meanLongitude := math.Mod(280.46646+c*(36000.76983+c*0.0003032), 360)
+	meanAnomaly := 357.52911 + c*(35999.05029-0.0001537*c)
+	eccentricity := 0.016708634 - c*(0.000042037+0.0000001267*c)
+	center := sin(meanAnomaly)*(1.914602-c*(0.004817+0.000014*c)) +
+		sin(2*meanAnomaly)*(0.019993-0.000101*c) +
+		sin(3*meanAnomaly)*0.000289
+	omega := 125.04 - 1934.136*c
+	apparentLongitude := meanLongitude + center - 0.00569 - 0.00478*sin(omega)
+	meanObliquity := 23 + (26+(21.448-c*(46.815+c*(0.00059-c*0.001813)))/60)/60
+	obliquity := meanObliquity + 0.00256*cos(omega)
+
+	declination = asin(sin(obliquity) * sin(apparentLongitude))
+	y := math.Pow(math.Tan(obliquity/2*math.Pi/180), 2)
+	equationOfTime = 4 * degrees(y*sin(2*meanLongitude)-
+		2*eccentricity*sin(meanAnomaly)+
+		4*eccentricity*y*sin(meanAnomaly)*cos(2*meanLongitude)-
+		0.5*y*y*sin(4*meanLongitude)-
+		1.25*eccentricity*eccentricity*sin(2*meanAnomaly))
+	return declination, equationOfTime
+}
+
+// solarPosition returns the elevation above the horizon, corrected for atmospheric refraction, and the
+// azimuth clockwise from north of the sun, both in degrees.
+func solarPosition(t time.Time, lat, lon float64) (elevation, azimuth float64) {
+	declination, equationOfTime := sunCoordinates(t)
+	utc := t.UTC()
+	minutes := float64(utc.Hour()*60+utc.Minute()) + float64(utc.Second())/60
+	trueSolarTime := math.Mod(minutes+equationOfTime+4*lon, 1440)
+	if trueSolarTime < 0 {
+		trueSolarTime += 1440
+	}
+	hourAngle := trueSolarTime/4 - 180
+
+	zenith := acos(sin(lat)*sin(declination) + cos(lat)*cos(declination)*cos(hourAngle))
+	elevation = 90 - zenith + refraction(90-zenith)
+
+	cosAzimuth := (sin(lat)*cos(zenith) - sin(declination)) / (cos(lat) * sin(zenith))
+	azimuth = acos(math.Max(-1, math.Min(1, cosAzimuth)))
+	if hourAngle > 0 {
+		azimuth = math.Mod(azimuth+180, 360)
+	} else {
+		azimuth = math.Mod(540-azimuth, 360)
+	}
+	return elevation, azimuth
+}
+
+// refraction returns the atmospheric refraction in degrees at the given true elevation.
+func refraction(elevation float64) float64 {
+	if elevation > 85 {
+		return 0
+	}
+	te := math.Tan(elevation * math.Pi / 180)
+	var arcSeconds float64
+	switch {
+	case elevation > 5:
+		arcSeconds = 58.1/te - 0.07/math.Pow(te, 3) + 0.000086/math.Pow(te, 5)
+	case elevation > -0.575:
+		arcSeconds = 1735 + elevation*(-518.2+elevation*(103.4+elevation*(-12.79+elevation*0.711)))
+	default:
+		arcSeconds = -20.772 / te
+	}
+	return arcSeconds / 3600
+}
+
+// solarDay returns sunrise and sunset of the solar day containing t, i.e. the day from local solar
+// midnight to local solar midnight, which does not depend on the time zone.
+func solarDay(t time.Time, lat, lon float64) sunDay {
+	solarDate := t.UTC().Add(time.Duration(lon * 4 * float64(time.Minute)))
+	midnight := time.Date(solarDate.Year(), solarDate.Month(), solarDate.Day(), 0, 0, 0, 0, time.UTC)
+	approxNoon := midnight.Add(time.Duration((720 - 4*lon) * float64(time.Minute)))
+
+	declination, equationOfTime := sunCoordinates(approxNoon)
+	noon := midnight.Add(time.Duration((720 - 4*lon - equationOfTime) * float64(time.Minute)))
+	cosHourAngle := cos(sunriseZenith)/(cos(lat)*cos(declination)) - math.Tan(lat*math.Pi/180)*math.Tan(declination*math.Pi/180)
+	switch {
+	case cosHourAngle < -1:
+		return sunDay{alwaysUp: true}
+	case cosHourAngle > 1:
+		return sunDay{alwaysDown: true}
+	}
+	halfDay := time.Duration(4 * acos(cosHourAngle) * float64(time.Minute))
+	return sunDay{sunrise: noon.Add(-halfDay), sunset: noon.Add(halfDay)}
+}
+
+func sin(deg float64) float64     { return math.Sin(deg * math.Pi / 180) }
+func cos(deg float64) float64     { return math.Cos(deg * math.Pi / 180) }
+func asin(x float64) float64      { return degrees(math.Asin(x)) }
+func acos(x float64) float64      { return degrees(math.Acos(x)) }
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
+
+// providerSunTimes keeps sunrise and sunset from the last weather data of each asset. They are preferred
+// over the computed ones while they belong to the current day.
+type providerSunTimes struct {
+	mu   sync.Mutex
+	days map[int64]sunDay
+}
+
+var sunTimes = &providerSunTimes{days: make(map[int64]sunDay)}
+
+func (p *providerSunTimes) update(assetID int64, sunrise, sunset int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if sunrise == 0 || sunset == 0 {
+		// Not reported in polar day and night.
+		delete(p.days, assetID)
+		return
+	}
+	p.days[assetID] = sunDay{sunrise: time.Unix(sunrise, 0), sunset: time.Unix(sunset, 0)}
+}
+
+// day returns the sun times for the asset at t, from the provider if it reported the same day.
+func (p *providerSunTimes) day(asset appmodel.Asset, t time.Time) sunDay {
+	computed := solarDay(t, asset.Lat, asset.Lon)
+	p.mu.Lock()
+	provided, ok := p.days[asset.ID]
+	p.mu.Unlock()
+	if ok && !computed.alwaysUp && !computed.alwaysDown && provided.sunset.Sub(computed.sunset).Abs() < time.Hour {
+		return provided
+	}
+	return computed
+}
+
+// solarData returns the sun attributes of a location at t.
+func solarData(asset appmodel.Asset, t time.Time) map[string]any {
+	elevation, azimuth := solarPosition(t, asset.Lat, asset.Lon)
+	day := sunTimes.day(asset, t)
+	data := map[string]any{
+		"solar_elevation": elevation,
+		"solar_azimuth":   azimuth,
+		"daylight":        0,
+		"day_length":      day.dayLength().Hours(),
+	}
+	if day.isDaylight(t) {
+		data["daylight"] = 1
+	}
+	if !day.alwaysUp {
+		data["minutes_to_sunset"] = math.Max(0, math.Ceil(day.sunset.Sub(t).Minutes()))
+	}
+	return data
+}
+
+// UpdateSolarPosition writes the sun's position and daylight of all locations. It runs independently of
+// the weather cycle and does not need the provider.
+func UpdateSolarPosition() {
+	config, err := dbhelper.GetConfig(context.Background())
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return
+	} else if err != nil {
+		log.Error("dbhelper", "getting config for solar position: %v", err)
+		return
+	}
+	if !config.Enable {
+		return
+	}
+	assets, err := dbhelper.GetAssets(context.Background())
+	if errors.Is(err, dbhelper.ErrNotFound) {
+		return
+	} else if err != nil {
+		log.Error("dbhelper", "getting assets for solar position: %v", err)
+		return
+	}
+
+	now := time.Now()
+	data := make(map[int32]map[string]any, len(assets))
+	for _, asset := range assets {
+		data[asset.AssetID] = solarData(asset, now)
+	}
+	if err := eliona.UpsertDataBulk(data, now, api.SUBTYPE_INPUT); err != nil {
+		log.Error("eliona", "upserting solar position: %v", err)
+	}
+}
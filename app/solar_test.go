@@ -0,0 +1,125 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"slices"
+	"testing"
+	"time"
+	appmodel "weather-app2/app/model"
+)
+
+const zurichLat, zurichLon = 47.3769, 8.5417
+
+func TestSolarDay(t *testing.T) {
+	tests := []struct {
+		name     string
+		at       time.Time
+		lat, lon float64
+		sunrise  time.Time
+		sunset   time.Time
+	}{
+		{
+			name: "Zurich summer solstice", at: time.Date(2024, 6, 21, 10, 0, 0, 0, time.UTC), lat: zurichLat, lon: zurichLon,
+			sunrise: time.Date(2024, 6, 21, 3, 30, 0, 0, time.UTC), sunset: time.Date(2024, 6, 21, 19, 26, 0, 0, time.UTC),
+		},
+		{
+			name: "Zurich winter solstice", at: time.Date(2024, 12, 21, 10, 0, 0, 0, time.UTC), lat: zurichLat, lon: zurichLon,
+			sunrise: time.Date(2024, 12, 21, 7, 10, 0, 0, time.UTC), sunset: time.Date(2024, 12, 21, 15, 38, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day := solarDay(tt.at, tt.lat, tt.lon)
+			if d := day.sunrise.Sub(tt.sunrise).Abs(); d > 3*time.Minute {
+				t.Errorf("sunrise %v, want %v", day.sunrise, tt.sunrise)
+			}
+			if d := day.sunset.Sub(tt.sunset).Abs(); d > 3*time.Minute {
+				t.Errorf("sunset %v, want %v", day.sunset, tt.sunset)
+			}
+		})
+	}
+}
+
+func TestSolarDayPolar(t *testing.T) {
+	const tromsoLat, tromsoLon = 69.6496, 18.9560
+	if day := solarDay(time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), tromsoLat, tromsoLon); !day.alwaysUp || day.dayLength() != 24*time.Hour {
+		t.Errorf("expected polar day in Tromsø in June, got %+v", day)
+	}
+	if day := solarDay(time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC), tromsoLat, tromsoLon); !day.alwaysDown || day.dayLength() != 0 {
+		t.Errorf("expected polar night in Tromsø in December, got %+v", day)
+	}
+}
+
+func TestSolarPosition(t *testing.T) {
+	// At solar noon of the summer solstice the sun stands due south at 90° - latitude + 23.44°.
+	noon := solarDay(time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), zurichLat, zurichLon)
+	at := noon.sunrise.Add(noon.sunset.Sub(noon.sunrise) / 2)
+	elevation, azimuth := solarPosition(at, zurichLat, zurichLon)
+	assertNear(t, "noon elevation", elevation, 90-zurichLat+23.44, 0.1)
+	assertNear(t, "noon azimuth", azimuth, 180, 0.5)
+
+	// At sunrise the sun's center is just below the horizon in the north-east.
+	elevation, azimuth = solarPosition(noon.sunrise, zurichLat, zurichLon)
+	if elevation < -0.833 || elevation > 0 {
+		t.Errorf("sunrise elevation %.2f°, want just below the horizon", elevation)
+	}
+	if azimuth < 45 || azimuth > 65 {
+		t.Errorf("sunrise azimuth %.1f°, want north-east", azimuth)
+	}
+
+	elevation, _ = solarPosition(time.Date(2024, 6, 21, 23, 0, 0, 0, time.UTC), zurichLat, zurichLon)
+	if elevation > -10 {
+		t.Errorf("elevation at night %.1f°, want below the horizon", elevation)
+	}
+}
+
+func TestSolarData(t *testing.T) {
+	asset := appmodel.Asset{ID: 1, Lat: zurichLat, Lon: zurichLon}
+	afternoon := time.Date(2024, 6, 21, 17, 26, 0, 0, time.UTC)
+	data := solarData(asset, afternoon)
+	if data["daylight"] != 1 {
+		t.Errorf("daylight %v in the afternoon", data["daylight"])
+	}
+	assertNear(t, "minutes to sunset", data["minutes_to_sunset"].(float64), 120, 3)
+	assertNear(t, "day length", data["day_length"].(float64), 15.93, 0.05)
+
+	data = solarData(asset, afternoon.Add(3*time.Hour))
+	if data["daylight"] != 0 || data["minutes_to_sunset"] != 0.0 {
+		t.Errorf("daylight %v and %v minutes to sunset after sunset", data["daylight"], data["minutes_to_sunset"])
+	}
+
+	for attribute := range data {
+		if !slices.Contains(appmodel.SolarAttributes, attribute) {
+			t.Errorf("%s is not in appmodel.SolarAttributes, so rules and alerts could watch it", attribute)
+		}
+	}
+}
+
+func TestProviderSunTimesPreferred(t *testing.T) {
+	asset := appmodel.Asset{ID: 2, Lat: zurichLat, Lon: zurichLon}
+	at := time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC)
+	sunrise := time.Date(2024, 6, 21, 3, 31, 0, 0, time.UTC)
+	sunset := time.Date(2024, 6, 21, 19, 25, 0, 0, time.UTC)
+	sunTimes.update(asset.ID, sunrise.Unix(), sunset.Unix())
+	if day := sunTimes.day(asset, at); !day.sunset.Equal(sunset) {
+		t.Errorf("sunset %v, want the provider's %v", day.sunset, sunset)
+	}
+	// A day later the provider's times are outdated and the computed ones are used.
+	if day := sunTimes.day(asset, at.AddDate(0, 0, 1)); day.sunset.Equal(sunset.AddDate(0, 0, 1)) || day.sunset.Before(at) {
+		t.Errorf("sunset %v, want the computed one of the next day", day.sunset)
+	}
+}
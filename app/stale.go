@@ -0,0 +1,135 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	appmodel "weather-app2/app/model"
+	"weather-app2/eliona"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// locationFreshness tracks when a location was last updated and how old the provider's data was.
+type locationFreshness struct {
+	lastUpdate time.Time
+	observedAt time.Time
+	stale      bool
+	known      bool
+}
+
+// staleTracker detects locations whose weather is not up to date anymore.
+type staleTracker struct {
+	mu        sync.Mutex
+	locations map[int32]*locationFreshness
+}
+
+var staleness = staleTracker{locations: make(map[int32]*locationFreshness)}
+
+// updated records a successful update of the location with the observation time reported by the provider.
+func (t *staleTracker) updated(assetID int32, observedAt time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	f := t.location(assetID)
+	f.lastUpdate = time.Now()
+	f.observedAt = observedAt
+}
+
+func (t *staleTracker) location(assetID int32) *locationFreshness {
+	f, ok := t.locations[assetID]
+	if !ok {
+		// Locations count as updated at start, so they get the full time to be updated.
+		f = &locationFreshness{lastUpdate: startedAt}
+		t.locations[assetID] = f
+	}
+	return f
+}
+
+// staleChange is a location whose stale state changed.
+type staleChange struct {
+	asset  appmodel.Asset
+	stale  bool
+	reason string
+}
+
+// evaluate checks all locations against the thresholds of the configuration. It returns the
+// locations whose state changed, including all seen for the first time, and the number of stale locations.
+func (t *staleTracker) evaluate(config *appmodel.Configuration, assets []appmodel.Asset, now time.Time) ([]staleChange, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var changes []staleChange
+	staleCount := 0
+	present := make(map[int32]bool, len(assets))
+	for _, asset := range assets {
+		present[asset.AssetID] = true
+		f := t.location(asset.AssetID)
+		reason := staleReason(config, f, now)
+		stale := reason != ""
+		if stale {
+			staleCount++
+		}
+		if stale != f.stale || !f.known {
+			changes = append(changes, staleChange{asset: asset, stale: stale, reason: reason})
+		}
+		f.stale = stale
+		f.known = true
+	}
+	for assetID := range t.locations {
+		if !present[assetID] {
+			delete(t.locations, assetID)
+		}
+	}
+	return changes, staleCount
+}
+
+func staleReason(config *appmodel.Configuration, f *locationFreshness, now time.Time) string {
+	if config.StaleAfterIntervals > 0 {
+		maxAge := time.Duration(config.StaleAfterIntervals) * time.Duration(config.RefreshInterval) * time.Second
+		if now.Sub(f.lastUpdate) > maxAge {
+			return fmt.Sprintf("no successful update since %s", f.lastUpdate.Format(time.RFC3339))
+		}
+	}
+	if config.MaxDataAge > 0 && !f.observedAt.IsZero() {
+		if now.Sub(f.observedAt) > time.Duration(config.MaxDataAge)*time.Second {
+			return fmt.Sprintf("provider data from %s", f.observedAt.Format(time.RFC3339))
+		}
+	}
+	return ""
+}
+
+// markStaleLocations writes the stale flag of the changed locations and notifies the user about
+// newly stale locations if configured.
+func markStaleLocations(config *appmodel.Configuration, changes []staleChange) {
+	for _, change := range changes {
+		value := 0
+		if change.stale {
+			value = 1
+			log.Warn("app", "Weather of location %s is stale: %s", change.asset.LocationName, change.reason)
+		}
+		if err := eliona.UpsertData(change.asset.AssetID, map[string]any{"stale": value}, time.Now(), api.SUBTYPE_STATUS); err != nil {
+			log.Error("eliona", "marking asset %v as stale: %v", change.asset.AssetID, err)
+		}
+		if change.stale && config.NotifyStale && config.UserId != "" {
+			if err := eliona.NotifyStaleData(config.UserId, change.asset.ProjectID, change.asset.LocationName, change.reason); err != nil {
+				log.Error("eliona", "notifying about stale location %s: %v", change.asset.LocationName, err)
+			}
+		}
+	}
+}
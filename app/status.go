@@ -0,0 +1,155 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"os"
+	"sync"
+	"time"
+	"weather-app2/broker"
+	"weather-app2/metrics"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+const (
+	statusOK = iota
+	statusError
+	statusFatal
+)
+
+// cycleResult summarizes one collection cycle.
+type cycleResult struct {
+	finished  time.Time
+	duration  time.Duration
+	locations int
+	failing   int
+	stale     int
+	lastErr   error
+}
+
+// appStatus holds the state of the app written to the root asset. All access goes through its
+// methods, as it is updated from the collection, the listener and the API server.
+type appStatus struct {
+	mu                  sync.Mutex
+	code                int
+	lastSuccessfulCycle time.Time
+	cycleDuration       time.Duration
+	locationCount       int
+	failingCount        int
+	staleCount          int
+	lastError           string
+}
+
+var status appStatus
+
+// publishStatus writes the status to the root assets, exitApp ends the app after a fatal error. Both are
+// replaced in tests.
+var (
+	publishStatus = Heartbeat
+	exitApp       = os.Exit
+)
+
+// reportCycle sets the status from a finished cycle. The cycle is successful if it updated at least one
+// location, so that a single broken location doesn't hide that the others are updated. Failing and stale
+// locations degrade the status to error, and the last error is kept until a cycle has none.
+func (s *appStatus) reportCycle(result cycleResult) {
+	s.mu.Lock()
+	s.cycleDuration = result.duration
+	s.locationCount = result.locations
+	s.failingCount = result.failing
+	s.staleCount = result.stale
+	s.lastError = ""
+	if result.lastErr != nil {
+		s.lastError = result.lastErr.Error()
+	}
+	if result.locations == 0 || result.failing < result.locations {
+		s.lastSuccessfulCycle = result.finished
+	}
+	if result.failing == 0 && result.stale == 0 {
+		s.code = statusOK
+	} else {
+		s.code = statusError
+	}
+	s.mu.Unlock()
+	s.changed()
+}
+
+// reportError sets the status to error unless it is fatal already.
+func (s *appStatus) reportError(err error) {
+	s.report(statusError, err)
+}
+
+// reportFatal sets the status to fatal until the next successful cycle.
+func (s *appStatus) reportFatal(err error) {
+	s.report(statusFatal, err)
+}
+
+// exitFatal reports a fatal error on the root assets before it exits the app, as log.Fatal would exit
+// before the status is written.
+func exitFatal(tag string, err error) {
+	log.Error(tag, "Exiting: %v", err)
+	status.reportFatal(err)
+	exitApp(1)
+}
+
+func (s *appStatus) report(code int, err error) {
+	s.mu.Lock()
+	if code > s.code {
+		s.code = code
+	}
+	if err != nil {
+		s.lastError = err.Error()
+	}
+	s.mu.Unlock()
+	s.changed()
+}
+
+func (s *appStatus) changed() {
+	metrics.SetAppStatus(s.getCode())
+	publishStatus()
+}
+
+func (s *appStatus) getCode() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.code
+}
+
+func (s *appStatus) getLastSuccessfulCycle() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastSuccessfulCycle
+}
+
+// rootData returns the status attributes of the root asset.
+func (s *appStatus) rootData() map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data := map[string]any{
+		"status":                 s.code,
+		"cycle_duration":         s.cycleDuration.Seconds(),
+		"location_count":         s.locationCount,
+		"failing_location_count": s.failingCount,
+		"stale_location_count":   s.staleCount,
+		"quota_remaining":        max(broker.DailyQuota()-metrics.QuotaUsed(), 0),
+		"last_error":             s.lastError,
+	}
+	if !s.lastSuccessfulCycle.IsZero() {
+		data["last_successful_cycle"] = s.lastSuccessfulCycle.Format(time.RFC3339)
+	}
+	return data
+}
@@ -0,0 +1,56 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	appmodel "weather-app2/app/model"
+)
+
+func TestConfigReadFailureIsFatal(t *testing.T) {
+	originalReadConfig, originalPublishStatus, originalExitApp := readConfig, publishStatus, exitApp
+	t.Cleanup(func() {
+		readConfig, publishStatus, exitApp = originalReadConfig, originalPublishStatus, originalExitApp
+		status = appStatus{}
+	})
+
+	var published map[string]any
+	exitCode := -1
+	readConfig = func(context.Context) (appmodel.Configuration, error) {
+		return appmodel.Configuration{}, errors.New("connection refused")
+	}
+	publishStatus = func() { published = status.rootData() }
+	exitApp = func(code int) { exitCode = code }
+	status = appStatus{}
+
+	CollectData()
+
+	if exitCode != 1 {
+		t.Errorf("exit code %d, want 1", exitCode)
+	}
+	if published == nil {
+		t.Fatal("status not written to the root assets before exiting")
+	}
+	if published["status"] != statusFatal {
+		t.Errorf("root asset status %v, want %d (fatal)", published["status"], statusFatal)
+	}
+	if lastError, _ := published["last_error"].(string); !strings.Contains(lastError, "connection refused") {
+		t.Errorf("last error %q, want the config read error", lastError)
+	}
+}
@@ -21,23 +21,40 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync"
+	"time"
 	appmodel "weather-app2/app/model"
+	"weather-app2/metrics"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
 )
 
+// TestAuthentication checks the API key of a configuration that is about to be saved. Its result doesn't
+// change AuthenticationError, which is about the key in use.
 func TestAuthentication(config appmodel.Configuration) error {
-	_, err := getGeolocation("Winterthur", config.ApiKey)
+	_, err := getGeolocation("Winterthur", config.ApiKey, false)
 	return err
 }
 
 func Locate(config appmodel.Configuration, name string) (Geolocation, error) {
-	locs, err := getGeolocation(name, config.ApiKey)
+	locs, err := Search(config, name)
+	if err != nil {
+		return Geolocation{}, err
+	}
+	return locs[0], err
+}
+
+// Search returns all locations matching the name, best match first.
+func Search(config appmodel.Configuration, name string) ([]Geolocation, error) {
+	locs, err := getGeolocation(name, config.ApiKey, true)
 	if err != nil {
-		return Geolocation{}, fmt.Errorf("getting location: %v", err)
+		return nil, fmt.Errorf("getting location: %v", err)
 	}
 	if len(locs) == 0 {
-		return Geolocation{}, fmt.Errorf("location not found")
+		return nil, fmt.Errorf("location not found")
 	}
-	return locs[0], err
+	return locs, nil
 }
 
 type Geolocation struct {
@@ -49,36 +66,159 @@ type Geolocation struct {
 	State      string            `json:"state"`
 }
 
+// FormattedName returns the name of the location including state and country.
+func (g Geolocation) FormattedName() string {
+	return fmt.Sprintf("%s, %s, %s", g.Name, g.State, g.Country)
+}
+
 type WeatherData struct {
-	Current struct {
-		Dt         int64   `json:"dt"`
-		Sunrise    int64   `json:"sunrise"`
-		Sunset     int64   `json:"sunset"`
-		Temp       float64 `json:"temp"`
-		FeelsLike  float64 `json:"feels_like"`
-		Pressure   int     `json:"pressure"`
-		Humidity   int     `json:"humidity"`
-		DewPoint   float64 `json:"dew_point"`
-		Uvi        float64 `json:"uvi"`
-		Clouds     int     `json:"clouds"`
-		Visibility int     `json:"visibility"`
-		WindSpeed  float64 `json:"wind_speed"`
-		WindDeg    int     `json:"wind_deg"`
-		Weather    []struct {
-			Main        string `json:"main"`
-			Description string `json:"description"`
-		} `json:"weather"`
-	} `json:"current"`
-}
-
-func getGeolocation(location string, apiKey string) ([]Geolocation, error) {
+	// Timezone is the IANA time zone of the location, e.g. "Europe/Zurich".
+	Timezone string         `json:"timezone"`
+	Current  CurrentWeather `json:"current"`
+	// Minutely is the precipitation nowcast for the next hour. It is missing where the provider has none.
+	Minutely []MinutelyWeather `json:"minutely"`
+	Hourly   []HourlyWeather   `json:"hourly"`
+	Daily    []DailyWeather    `json:"daily"`
+}
+
+type MinutelyWeather struct {
+	Dt int64 `json:"dt"`
+	// Precipitation is the intensity in mm/h.
+	Precipitation float64 `json:"precipitation"`
+}
+
+type CurrentWeather struct {
+	Dt         int64       `json:"dt"`
+	Sunrise    int64       `json:"sunrise"`
+	Sunset     int64       `json:"sunset"`
+	Temp       float64     `json:"temp"`
+	FeelsLike  float64     `json:"feels_like"`
+	Pressure   int         `json:"pressure"`
+	Humidity   int         `json:"humidity"`
+	DewPoint   float64     `json:"dew_point"`
+	Uvi        float64     `json:"uvi"`
+	Clouds     int         `json:"clouds"`
+	Visibility int         `json:"visibility"`
+	WindSpeed  float64     `json:"wind_speed"`
+	WindDeg    int         `json:"wind_deg"`
+	WindGust   float64     `json:"wind_gust"`
+	Rain       Volume      `json:"rain"`
+	Snow       Volume      `json:"snow"`
+	Weather    []Condition `json:"weather"`
+}
+
+type HourlyWeather struct {
+	Dt         int64       `json:"dt"`
+	Temp       float64     `json:"temp"`
+	FeelsLike  float64     `json:"feels_like"`
+	Pressure   int         `json:"pressure"`
+	Humidity   int         `json:"humidity"`
+	DewPoint   float64     `json:"dew_point"`
+	Uvi        float64     `json:"uvi"`
+	Clouds     int         `json:"clouds"`
+	Visibility int         `json:"visibility"`
+	WindSpeed  float64     `json:"wind_speed"`
+	WindDeg    int         `json:"wind_deg"`
+	WindGust   float64     `json:"wind_gust"`
+	Pop        float64     `json:"pop"`
+	Rain       Volume      `json:"rain"`
+	Snow       Volume      `json:"snow"`
+	Weather    []Condition `json:"weather"`
+}
+
+type DailyWeather struct {
+	Dt      int64 `json:"dt"`
+	Sunrise int64 `json:"sunrise"`
+	Sunset  int64 `json:"sunset"`
+	Temp    struct {
+		Day   float64 `json:"day"`
+		Min   float64 `json:"min"`
+		Max   float64 `json:"max"`
+		Night float64 `json:"night"`
+		Eve   float64 `json:"eve"`
+		Morn  float64 `json:"morn"`
+	} `json:"temp"`
+	Pressure  int         `json:"pressure"`
+	Humidity  int         `json:"humidity"`
+	DewPoint  float64     `json:"dew_point"`
+	WindSpeed float64     `json:"wind_speed"`
+	WindDeg   int         `json:"wind_deg"`
+	WindGust  float64     `json:"wind_gust"`
+	Clouds    int         `json:"clouds"`
+	Pop       float64     `json:"pop"`
+	Rain      float64     `json:"rain"`
+	Snow      float64     `json:"snow"`
+	Uvi       float64     `json:"uvi"`
+	Weather   []Condition `json:"weather"`
+}
+
+type Condition struct {
+	Main        string `json:"main"`
+	Description string `json:"description"`
+}
+
+// Volume is the precipitation volume in mm for the last hour.
+type Volume struct {
+	OneHour float64 `json:"1h"`
+}
+
+// get sends a GET request to the provider and records it in the metrics. Unless the request tests a
+// candidate API key, it records whether the provider accepted the key for AuthenticationError.
+func get(endpoint, rawURL string, recordAuthentication bool) (*http.Response, error) {
+	start := time.Now()
+	resp, err := http.Get(rawURL)
+	statusCode := 0
+	if err == nil {
+		statusCode = resp.StatusCode
+	}
+	metrics.ObserveProviderRequest(endpoint, statusCode, time.Since(start))
+	switch {
+	case !recordAuthentication:
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		setAuthenticationError(fmt.Errorf("provider rejected the API key: %s", resp.Status))
+	case statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices:
+		setAuthenticationError(nil)
+	}
+	return resp, err
+}
+
+var authentication struct {
+	sync.Mutex
+	err error
+}
+
+func setAuthenticationError(err error) {
+	authentication.Lock()
+	defer authentication.Unlock()
+	authentication.err = err
+}
+
+// DailyQuota returns the number of One Call requests per day allowed by the subscription,
+// configured by PROVIDER_DAILY_QUOTA. The default is the free tier's 1000 requests.
+func DailyQuota() int {
+	quota, err := strconv.Atoi(common.Getenv("PROVIDER_DAILY_QUOTA", "1000"))
+	if err != nil {
+		return 1000
+	}
+	return quota
+}
+
+// AuthenticationError returns the error of the last request rejected because of the API key,
+// or nil if the provider accepted the last request.
+func AuthenticationError() error {
+	authentication.Lock()
+	defer authentication.Unlock()
+	return authentication.err
+}
+
+func getGeolocation(location string, apiKey string, recordAuthentication bool) ([]Geolocation, error) {
 	baseURL := "http://api.openweathermap.org/geo/1.0/direct"
 	params := url.Values{}
 	params.Add("q", location)
 	params.Add("limit", "10")
 	params.Add("appid", apiKey)
 
-	resp, err := http.Get(fmt.Sprintf("%s?%s", baseURL, params.Encode()))
+	resp, err := get("geocoding", fmt.Sprintf("%s?%s", baseURL, params.Encode()), recordAuthentication)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %v", err)
 	}
@@ -102,16 +242,28 @@ func getGeolocation(location string, apiKey string) ([]Geolocation, error) {
 	return geolocations, nil
 }
 
+// GetWeather returns the current weather for the coordinates.
 func GetWeather(lat, lon float64, apiKey string) (WeatherData, error) {
+	return getOneCall(lat, lon, apiKey, "minutely,hourly,daily,alerts")
+}
+
+// GetWeatherWithForecast returns the current weather along with the minutely precipitation nowcast and
+// the hourly and daily forecast.
+func GetWeatherWithForecast(lat, lon float64, apiKey string) (WeatherData, error) {
+	return getOneCall(lat, lon, apiKey, "alerts")
+}
+
+func getOneCall(lat, lon float64, apiKey string, exclude string) (WeatherData, error) {
 	baseURL := "https://api.openweathermap.org/data/3.0/onecall"
 	params := url.Values{}
 	params.Add("lat", fmt.Sprintf("%f", lat))
 	params.Add("lon", fmt.Sprintf("%f", lon))
-	params.Add("exclude", "minutely,hourly,daily,alerts")
+	params.Add("exclude", exclude)
 	params.Add("units", "metric")
 	params.Add("appid", apiKey)
 
-	resp, err := http.Get(fmt.Sprintf("%s?%s", baseURL, params.Encode()))
+	metrics.CountQuotaRequest()
+	resp, err := get("onecall", fmt.Sprintf("%s?%s", baseURL, params.Encode()), true)
 	if err != nil {
 		return WeatherData{}, fmt.Errorf("failed to make request: %v", err)
 	}
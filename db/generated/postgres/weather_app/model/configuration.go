@@ -12,12 +12,19 @@ import (
 )
 
 type Configuration struct {
-	ID              int32 `sql:"primary_key"`
-	APIKey          string
-	RefreshInterval int32
-	RequestTimeout  int32
-	Active          bool
-	Enable          bool
-	ProjectIds      pq.StringArray
-	UserID          string
+	ID                  int32 `sql:"primary_key"`
+	APIKey              string
+	RefreshInterval     int32
+	RequestTimeout      int32
+	Active              bool
+	Enable              bool
+	ProjectIds          pq.StringArray
+	UserID              string
+	StaleAfterIntervals int32
+	MaxDataAge          int32
+	NotifyStale         bool
+	PlausibilityLimits  string
+	DegreeDayBases      string
+	Rules               string
+	Alerts              string
 }
@@ -17,14 +17,21 @@ type configurationTable struct {
 	postgres.Table
 
 	// Columns
-	ID              postgres.ColumnInteger
-	APIKey          postgres.ColumnString
-	RefreshInterval postgres.ColumnInteger
-	RequestTimeout  postgres.ColumnInteger
-	Active          postgres.ColumnBool
-	Enable          postgres.ColumnBool
-	ProjectIds      postgres.ColumnString
-	UserID          postgres.ColumnString
+	ID                  postgres.ColumnInteger
+	APIKey              postgres.ColumnString
+	RefreshInterval     postgres.ColumnInteger
+	RequestTimeout      postgres.ColumnInteger
+	Active              postgres.ColumnBool
+	Enable              postgres.ColumnBool
+	ProjectIds          postgres.ColumnString
+	UserID              postgres.ColumnString
+	StaleAfterIntervals postgres.ColumnInteger
+	MaxDataAge          postgres.ColumnInteger
+	NotifyStale         postgres.ColumnBool
+	PlausibilityLimits  postgres.ColumnString
+	DegreeDayBases      postgres.ColumnString
+	Rules               postgres.ColumnString
+	Alerts              postgres.ColumnString
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -66,31 +73,45 @@ func newConfigurationTable(schemaName, tableName, alias string) *ConfigurationTa
 
 func newConfigurationTableImpl(schemaName, tableName, alias string) configurationTable {
 	var (
-		IDColumn              = postgres.IntegerColumn("id")
-		APIKeyColumn          = postgres.StringColumn("api_key")
-		RefreshIntervalColumn = postgres.IntegerColumn("refresh_interval")
-		RequestTimeoutColumn  = postgres.IntegerColumn("request_timeout")
-		ActiveColumn          = postgres.BoolColumn("active")
-		EnableColumn          = postgres.BoolColumn("enable")
-		ProjectIdsColumn      = postgres.StringColumn("project_ids")
-		UserIDColumn          = postgres.StringColumn("user_id")
-		allColumns            = postgres.ColumnList{IDColumn, APIKeyColumn, RefreshIntervalColumn, RequestTimeoutColumn, ActiveColumn, EnableColumn, ProjectIdsColumn, UserIDColumn}
-		mutableColumns        = postgres.ColumnList{APIKeyColumn, RefreshIntervalColumn, RequestTimeoutColumn, ActiveColumn, EnableColumn, ProjectIdsColumn, UserIDColumn}
-		defaultColumns        = postgres.ColumnList{IDColumn, RefreshIntervalColumn, RequestTimeoutColumn, ActiveColumn, EnableColumn}
+		IDColumn                  = postgres.IntegerColumn("id")
+		APIKeyColumn              = postgres.StringColumn("api_key")
+		RefreshIntervalColumn     = postgres.IntegerColumn("refresh_interval")
+		RequestTimeoutColumn      = postgres.IntegerColumn("request_timeout")
+		ActiveColumn              = postgres.BoolColumn("active")
+		EnableColumn              = postgres.BoolColumn("enable")
+		ProjectIdsColumn          = postgres.StringColumn("project_ids")
+		UserIDColumn              = postgres.StringColumn("user_id")
+		StaleAfterIntervalsColumn = postgres.IntegerColumn("stale_after_intervals")
+		MaxDataAgeColumn          = postgres.IntegerColumn("max_data_age")
+		NotifyStaleColumn         = postgres.BoolColumn("notify_stale")
+		PlausibilityLimitsColumn  = postgres.StringColumn("plausibility_limits")
+		DegreeDayBasesColumn      = postgres.StringColumn("degree_day_bases")
+		RulesColumn               = postgres.StringColumn("rules")
+		AlertsColumn              = postgres.StringColumn("alerts")
+		allColumns                = postgres.ColumnList{IDColumn, APIKeyColumn, RefreshIntervalColumn, RequestTimeoutColumn, ActiveColumn, EnableColumn, ProjectIdsColumn, UserIDColumn, StaleAfterIntervalsColumn, MaxDataAgeColumn, NotifyStaleColumn, PlausibilityLimitsColumn, DegreeDayBasesColumn, RulesColumn, AlertsColumn}
+		mutableColumns            = postgres.ColumnList{APIKeyColumn, RefreshIntervalColumn, RequestTimeoutColumn, ActiveColumn, EnableColumn, ProjectIdsColumn, UserIDColumn, StaleAfterIntervalsColumn, MaxDataAgeColumn, NotifyStaleColumn, PlausibilityLimitsColumn, DegreeDayBasesColumn, RulesColumn, AlertsColumn}
+		defaultColumns            = postgres.ColumnList{IDColumn, RefreshIntervalColumn, RequestTimeoutColumn, ActiveColumn, EnableColumn, StaleAfterIntervalsColumn, MaxDataAgeColumn, NotifyStaleColumn, PlausibilityLimitsColumn, DegreeDayBasesColumn, RulesColumn, AlertsColumn}
 	)
 
 	return configurationTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:              IDColumn,
-		APIKey:          APIKeyColumn,
-		RefreshInterval: RefreshIntervalColumn,
-		RequestTimeout:  RequestTimeoutColumn,
-		Active:          ActiveColumn,
-		Enable:          EnableColumn,
-		ProjectIds:      ProjectIdsColumn,
-		UserID:          UserIDColumn,
+		ID:                  IDColumn,
+		APIKey:              APIKeyColumn,
+		RefreshInterval:     RefreshIntervalColumn,
+		RequestTimeout:      RequestTimeoutColumn,
+		Active:              ActiveColumn,
+		Enable:              EnableColumn,
+		ProjectIds:          ProjectIdsColumn,
+		UserID:              UserIDColumn,
+		StaleAfterIntervals: StaleAfterIntervalsColumn,
+		MaxDataAge:          MaxDataAgeColumn,
+		NotifyStale:         NotifyStaleColumn,
+		PlausibilityLimits:  PlausibilityLimitsColumn,
+		DegreeDayBases:      DegreeDayBasesColumn,
+		Rules:               RulesColumn,
+		Alerts:              AlertsColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
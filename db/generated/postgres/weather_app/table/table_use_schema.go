@@ -12,5 +12,7 @@ package table
 func UseSchema(schema string) {
 	Asset = Asset.FromSchema(schema)
 	Configuration = Configuration.FromSchema(schema)
+	ConfigurationHistory = ConfigurationHistory.FromSchema(schema)
+	Observation = Observation.FromSchema(schema)
 	RootAsset = RootAsset.FromSchema(schema)
 }
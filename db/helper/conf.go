@@ -18,8 +18,11 @@ package dbhelper
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
+	"slices"
 	appmodel "weather-app2/app/model"
 
 	"github.com/eliona-smart-building-assistant/go-eliona/frontend"
@@ -65,10 +68,44 @@ func CloseDB() error {
 	return nil
 }
 
+// Ping checks whether the database is reachable.
+func Ping(ctx context.Context) error {
+	return GetDB().db.PingContext(ctx)
+}
+
 var ErrBadRequest = errors.New("bad request")
 var ErrNotFound = errors.New("not found")
 
+// UpsertConfig stores the configuration and records the change in the configuration history. It returns
+// ErrNoEncryptionKey if the API key can't be encrypted because CONFIG_ENCRYPTION_KEY is not set.
 func UpsertConfig(ctx context.Context, config appmodel.Configuration) (appmodel.Configuration, error) {
+	apiKey, err := encryptSecret(config.ApiKey)
+	if errors.Is(err, ErrNoEncryptionKey) {
+		return appmodel.Configuration{}, err
+	} else if err != nil {
+		return appmodel.Configuration{}, fmt.Errorf("encrypting API key: %v", err)
+	}
+	plausibilityLimits, err := marshalJSONMap("plausibility limits", config.PlausibilityLimits)
+	if err != nil {
+		return appmodel.Configuration{}, err
+	}
+	degreeDayBases, err := marshalJSONMap("degree day bases", config.DegreeDayBases)
+	if err != nil {
+		return appmodel.Configuration{}, err
+	}
+	rules, err := marshalJSONMap("rules", config.Rules)
+	if err != nil {
+		return appmodel.Configuration{}, err
+	}
+	alerts, err := marshalJSONMap("alerts", config.Alerts)
+	if err != nil {
+		return appmodel.Configuration{}, err
+	}
+	var userID string
+	if env := frontend.GetEnvironment(ctx); env != nil {
+		userID = env.UserId
+	}
+
 	commonColumns := ColumnList{
 		Configuration.APIKey,
 		Configuration.RefreshInterval,
@@ -77,16 +114,30 @@ func UpsertConfig(ctx context.Context, config appmodel.Configuration) (appmodel.
 		Configuration.Enable,
 		Configuration.ProjectIds,
 		Configuration.UserID,
+		Configuration.StaleAfterIntervals,
+		Configuration.MaxDataAge,
+		Configuration.NotifyStale,
+		Configuration.PlausibilityLimits,
+		Configuration.DegreeDayBases,
+		Configuration.Rules,
+		Configuration.Alerts,
 	}
 
 	commonValues := []interface{}{
-		config.ApiKey,
+		apiKey,
 		config.RefreshInterval,
 		config.RequestTimeout,
 		config.Active,
 		config.Enable,
 		pq.StringArray(config.ProjectIDs),
-		frontend.GetEnvironment(ctx).UserId,
+		userID,
+		config.StaleAfterIntervals,
+		config.MaxDataAge,
+		config.NotifyStale,
+		StringExp(CAST(String(plausibilityLimits)).AS("jsonb")),
+		StringExp(CAST(String(degreeDayBases)).AS("jsonb")),
+		StringExp(CAST(String(rules)).AS("jsonb")),
+		StringExp(CAST(String(alerts)).AS("jsonb")),
 	}
 
 	stmt := Configuration.INSERT()
@@ -106,6 +157,15 @@ func UpsertConfig(ctx context.Context, config appmodel.Configuration) (appmodel.
 				Configuration.Active.SET(Configuration.EXCLUDED.Active),
 				Configuration.Enable.SET(Configuration.EXCLUDED.Enable),
 				Configuration.ProjectIds.SET(Configuration.EXCLUDED.ProjectIds),
+				// Saves without a frontend environment, e.g. by API token, keep the user who saved last.
+				Configuration.UserID.SET(StringExp(COALESCE(NULLIF(Configuration.EXCLUDED.UserID, String("")), Configuration.UserID))),
+				Configuration.StaleAfterIntervals.SET(Configuration.EXCLUDED.StaleAfterIntervals),
+				Configuration.MaxDataAge.SET(Configuration.EXCLUDED.MaxDataAge),
+				Configuration.NotifyStale.SET(Configuration.EXCLUDED.NotifyStale),
+				Configuration.PlausibilityLimits.SET(Configuration.EXCLUDED.PlausibilityLimits),
+				Configuration.DegreeDayBases.SET(Configuration.EXCLUDED.DegreeDayBases),
+				Configuration.Rules.SET(Configuration.EXCLUDED.Rules),
+				Configuration.Alerts.SET(Configuration.EXCLUDED.Alerts),
 			),
 		)
 	} else {
@@ -115,12 +175,198 @@ func UpsertConfig(ctx context.Context, config appmodel.Configuration) (appmodel.
 
 	stmt = stmt.RETURNING(Configuration.AllColumns)
 
+	tx, err := GetDB().db.BeginTx(ctx, nil)
+	if err != nil {
+		return appmodel.Configuration{}, fmt.Errorf("starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	var previousConfig *appmodel.Configuration
+	var dbPreviousConfig model.Configuration
+	err = Configuration.SELECT(Configuration.AllColumns).FOR(UPDATE()).QueryContext(ctx, tx, &dbPreviousConfig)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return appmodel.Configuration{}, fmt.Errorf("getting previous config: %v", err)
+	} else if err == nil {
+		previous, err := toAppConfig(dbPreviousConfig)
+		if err != nil {
+			return appmodel.Configuration{}, err
+		}
+		previousConfig = &previous
+	}
+
 	var updatedConfig model.Configuration
-	if err := stmt.QueryContext(ctx, GetDB().db, &updatedConfig); err != nil {
+	if err := stmt.QueryContext(ctx, tx, &updatedConfig); err != nil {
 		return appmodel.Configuration{}, fmt.Errorf("upserting config: %v", err)
 	}
+	appConfig, err := toAppConfig(updatedConfig)
+	if err != nil {
+		return appmodel.Configuration{}, err
+	}
 
-	return toAppConfig(updatedConfig)
+	if diff := configDiff(previousConfig, appConfig); len(diff) > 0 {
+		diffJSON, err := json.Marshal(diff)
+		if err != nil {
+			return appmodel.Configuration{}, fmt.Errorf("marshalling config diff: %v", err)
+		}
+		// The history records the user stored with the configuration, which is the one who saved last if the
+		// request had no frontend environment.
+		historyStmt := ConfigurationHistory.INSERT(
+			ConfigurationHistory.UserID,
+			ConfigurationHistory.APIKey,
+			ConfigurationHistory.RefreshInterval,
+			ConfigurationHistory.RequestTimeout,
+			ConfigurationHistory.Enable,
+			ConfigurationHistory.ProjectIds,
+			ConfigurationHistory.Diff,
+			ConfigurationHistory.StaleAfterIntervals,
+			ConfigurationHistory.MaxDataAge,
+			ConfigurationHistory.NotifyStale,
+			ConfigurationHistory.PlausibilityLimits,
+			ConfigurationHistory.DegreeDayBases,
+			ConfigurationHistory.Rules,
+			ConfigurationHistory.Alerts,
+		).VALUES(
+			updatedConfig.UserID,
+			updatedConfig.APIKey,
+			updatedConfig.RefreshInterval,
+			updatedConfig.RequestTimeout,
+			updatedConfig.Enable,
+			updatedConfig.ProjectIds,
+			StringExp(CAST(String(string(diffJSON))).AS("jsonb")),
+			updatedConfig.StaleAfterIntervals,
+			updatedConfig.MaxDataAge,
+			updatedConfig.NotifyStale,
+			StringExp(CAST(String(updatedConfig.PlausibilityLimits)).AS("jsonb")),
+			StringExp(CAST(String(updatedConfig.DegreeDayBases)).AS("jsonb")),
+			StringExp(CAST(String(updatedConfig.Rules)).AS("jsonb")),
+			StringExp(CAST(String(updatedConfig.Alerts)).AS("jsonb")),
+		)
+		if _, err := historyStmt.ExecContext(ctx, tx); err != nil {
+			return appmodel.Configuration{}, fmt.Errorf("recording config history: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return appmodel.Configuration{}, fmt.Errorf("committing config: %v", err)
+	}
+	return appConfig, nil
+}
+
+// configDiff lists the fields that differ between the previous and the new configuration.
+// A nil previous configuration marks the first one, so all fields are listed.
+func configDiff(previous *appmodel.Configuration, config appmodel.Configuration) map[string]appmodel.ConfigurationChange {
+	diff := make(map[string]appmodel.ConfigurationChange)
+	add := func(field string, old, current any, changed bool) {
+		if previous == nil {
+			old = nil
+		} else if !changed {
+			return
+		}
+		diff[field] = appmodel.ConfigurationChange{Old: old, New: current}
+	}
+	if previous == nil {
+		previous = &appmodel.Configuration{}
+	}
+	add("apiKey", MaskSecret(previous.ApiKey), MaskSecret(config.ApiKey), previous.ApiKey != config.ApiKey)
+	add("refreshInterval", previous.RefreshInterval, config.RefreshInterval, previous.RefreshInterval != config.RefreshInterval)
+	add("requestTimeout", previous.RequestTimeout, config.RequestTimeout, previous.RequestTimeout != config.RequestTimeout)
+	add("enable", previous.Enable, config.Enable, previous.Enable != config.Enable)
+	add("projectIDs", previous.ProjectIDs, config.ProjectIDs, !slices.Equal(previous.ProjectIDs, config.ProjectIDs))
+	add("staleAfterIntervals", previous.StaleAfterIntervals, config.StaleAfterIntervals, previous.StaleAfterIntervals != config.StaleAfterIntervals)
+	add("maxDataAge", previous.MaxDataAge, config.MaxDataAge, previous.MaxDataAge != config.MaxDataAge)
+	add("notifyStale", previous.NotifyStale, config.NotifyStale, previous.NotifyStale != config.NotifyStale)
+	add("plausibilityLimits", previous.PlausibilityLimits, config.PlausibilityLimits, !reflect.DeepEqual(previous.PlausibilityLimits, config.PlausibilityLimits))
+	add("degreeDayBases", previous.DegreeDayBases, config.DegreeDayBases, !reflect.DeepEqual(previous.DegreeDayBases, config.DegreeDayBases))
+	add("rules", previous.Rules, config.Rules, !reflect.DeepEqual(previous.Rules, config.Rules))
+	add("alerts", previous.Alerts, config.Alerts, !reflect.DeepEqual(previous.Alerts, config.Alerts))
+	return diff
+}
+
+// GetConfigHistory returns all recorded configuration changes, newest first.
+func GetConfigHistory(ctx context.Context) ([]appmodel.ConfigurationRevision, error) {
+	var history []model.ConfigurationHistory
+	err := ConfigurationHistory.
+		SELECT(ConfigurationHistory.AllColumns).
+		ORDER_BY(ConfigurationHistory.ID.DESC()).
+		QueryContext(ctx, GetDB().db, &history)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, fmt.Errorf("fetching config history: %v", err)
+	}
+
+	revisions := make([]appmodel.ConfigurationRevision, 0, len(history))
+	for _, h := range history {
+		revision, err := toAppConfigRevision(h)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// GetConfigRevision returns the recorded configuration change with the given ID.
+func GetConfigRevision(ctx context.Context, id int64) (appmodel.ConfigurationRevision, error) {
+	var history model.ConfigurationHistory
+	err := ConfigurationHistory.
+		SELECT(ConfigurationHistory.AllColumns).
+		WHERE(ConfigurationHistory.ID.EQ(Int(id))).
+		QueryContext(ctx, GetDB().db, &history)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return appmodel.ConfigurationRevision{}, ErrNotFound
+	} else if err != nil {
+		return appmodel.ConfigurationRevision{}, fmt.Errorf("fetching config revision: %v", err)
+	}
+	return toAppConfigRevision(history)
+}
+
+func toAppConfigRevision(h model.ConfigurationHistory) (appmodel.ConfigurationRevision, error) {
+	var diff map[string]appmodel.ConfigurationChange
+	if err := json.Unmarshal([]byte(h.Diff), &diff); err != nil {
+		return appmodel.ConfigurationRevision{}, fmt.Errorf("unmarshalling diff of config revision %d: %v", h.ID, err)
+	}
+	apiKey, err := decryptSecret(h.APIKey)
+	if err != nil {
+		return appmodel.ConfigurationRevision{}, fmt.Errorf("decrypting API key of config revision %d: %v", h.ID, err)
+	}
+	plausibilityLimits, err := unmarshalJSONMap[appmodel.PlausibilityLimit]("plausibility limits", h.PlausibilityLimits)
+	if err != nil {
+		return appmodel.ConfigurationRevision{}, err
+	}
+	degreeDayBases, err := unmarshalJSONMap[appmodel.DegreeDayBase]("degree day bases", h.DegreeDayBases)
+	if err != nil {
+		return appmodel.ConfigurationRevision{}, err
+	}
+	rules, err := unmarshalJSONMap[appmodel.Rule]("rules", h.Rules)
+	if err != nil {
+		return appmodel.ConfigurationRevision{}, err
+	}
+	alerts, err := unmarshalJSONMap[appmodel.Alert]("alerts", h.Alerts)
+	if err != nil {
+		return appmodel.ConfigurationRevision{}, err
+	}
+	return appmodel.ConfigurationRevision{
+		ID:        h.ID,
+		ChangedAt: h.ChangedAt,
+		UserId:    h.UserID,
+		Diff:      diff,
+		Configuration: appmodel.Configuration{
+			Id:              1,
+			ApiKey:          apiKey,
+			RefreshInterval: h.RefreshInterval,
+			RequestTimeout:  h.RequestTimeout,
+			Enable:          h.Enable,
+			ProjectIDs:      h.ProjectIds,
+			UserId:          h.UserID,
+
+			StaleAfterIntervals: h.StaleAfterIntervals,
+			MaxDataAge:          h.MaxDataAge,
+			NotifyStale:         h.NotifyStale,
+			PlausibilityLimits:  plausibilityLimits,
+			DegreeDayBases:      degreeDayBases,
+			Rules:               rules,
+			Alerts:              alerts,
+		},
+	}, nil
 }
 
 func GetConfig(ctx context.Context) (appmodel.Configuration, error) {
@@ -137,6 +383,13 @@ func GetConfig(ctx context.Context) (appmodel.Configuration, error) {
 	return toAppConfig(dbConfig)
 }
 
+// DeleteConfig deletes the configuration together with its root asset mapping.
+func DeleteConfig(ctx context.Context) error {
+	stmt := Configuration.DELETE().WHERE(Bool(true))
+	_, err := stmt.ExecContext(ctx, GetDB().db)
+	return err
+}
+
 func SetConfigActiveState(ctx context.Context, state bool) error {
 	stmt := Configuration.UPDATE(Configuration.Active).
 		SET(state)
@@ -144,6 +397,59 @@ func SetConfigActiveState(ctx context.Context, state bool) error {
 	return err
 }
 
+// EncryptPlaintextSecrets encrypts API keys that earlier versions of the app stored in plain text, in the
+// configuration and its history. It returns the number of encrypted keys.
+func EncryptPlaintextSecrets(ctx context.Context) (int, error) {
+	tx, err := GetDB().db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	var configs []model.Configuration
+	err = Configuration.SELECT(Configuration.ID, Configuration.APIKey).
+		WHERE(Configuration.APIKey.NOT_EQ(String("")).AND(Configuration.APIKey.NOT_LIKE(String(encryptedPrefix+"%")))).
+		FOR(UPDATE()).
+		QueryContext(ctx, tx, &configs)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return 0, fmt.Errorf("getting plain text API keys: %v", err)
+	}
+	for _, config := range configs {
+		apiKey, err := encryptSecret(config.APIKey)
+		if err != nil {
+			return 0, err
+		}
+		stmt := Configuration.UPDATE(Configuration.APIKey).SET(String(apiKey)).WHERE(Configuration.ID.EQ(Int32(config.ID)))
+		if _, err := stmt.ExecContext(ctx, tx); err != nil {
+			return 0, fmt.Errorf("storing encrypted API key: %v", err)
+		}
+	}
+
+	var revisions []model.ConfigurationHistory
+	err = ConfigurationHistory.SELECT(ConfigurationHistory.ID, ConfigurationHistory.APIKey).
+		WHERE(ConfigurationHistory.APIKey.NOT_EQ(String("")).AND(ConfigurationHistory.APIKey.NOT_LIKE(String(encryptedPrefix+"%")))).
+		FOR(UPDATE()).
+		QueryContext(ctx, tx, &revisions)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return 0, fmt.Errorf("getting plain text API keys of config history: %v", err)
+	}
+	for _, revision := range revisions {
+		apiKey, err := encryptSecret(revision.APIKey)
+		if err != nil {
+			return 0, err
+		}
+		stmt := ConfigurationHistory.UPDATE(ConfigurationHistory.APIKey).SET(String(apiKey)).WHERE(ConfigurationHistory.ID.EQ(Int64(revision.ID)))
+		if _, err := stmt.ExecContext(ctx, tx); err != nil {
+			return 0, fmt.Errorf("storing encrypted API key of config history: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing encrypted API keys: %v", err)
+	}
+	return len(configs) + len(revisions), nil
+}
+
 func InsertAsset(ctx context.Context, asset appmodel.Asset) error {
 	stmt := Asset.INSERT(
 		Asset.ProjectID,
@@ -218,6 +524,39 @@ func GetAssetById(assetId int32) (appmodel.Asset, error) {
 	return toAppAsset(asset), nil
 }
 
+func GetAsset(ctx context.Context, id int64) (appmodel.Asset, error) {
+	var asset model.Asset
+	err := SELECT(
+		Asset.AllColumns,
+	).FROM(
+		Asset,
+	).WHERE(
+		Asset.ID.EQ(Int(id)),
+	).QueryContext(ctx, GetDB().db, &asset)
+	if errors.Is(err, qrm.ErrNoRows) {
+		return appmodel.Asset{}, ErrNotFound
+	} else if err != nil {
+		return appmodel.Asset{}, fmt.Errorf("fetching asset %v: %v", id, err)
+	}
+
+	return toAppAsset(asset), nil
+}
+
+func DeleteAsset(ctx context.Context, id int64) error {
+	stmt := Asset.DELETE().WHERE(
+		Asset.ID.EQ(Int(id)),
+	)
+	_, err := stmt.ExecContext(ctx, GetDB().db)
+	return err
+}
+
+// DeleteAssets deletes the mapping of all weather assets.
+func DeleteAssets(ctx context.Context) error {
+	stmt := Asset.DELETE().WHERE(Bool(true))
+	_, err := stmt.ExecContext(ctx, GetDB().db)
+	return err
+}
+
 func GetAssets(ctx context.Context) ([]appmodel.Asset, error) {
 	var assets []model.Asset
 	err := SELECT(
@@ -240,18 +579,73 @@ func GetAssets(ctx context.Context) ([]appmodel.Asset, error) {
 }
 
 func toAppConfig(dbCfg model.Configuration) (appmodel.Configuration, error) {
+	apiKey, err := decryptSecret(dbCfg.APIKey)
+	if err != nil {
+		return appmodel.Configuration{}, fmt.Errorf("decrypting API key: %v", err)
+	}
+	plausibilityLimits, err := unmarshalJSONMap[appmodel.PlausibilityLimit]("plausibility limits", dbCfg.PlausibilityLimits)
+	if err != nil {
+		return appmodel.Configuration{}, err
+	}
+	degreeDayBases, err := unmarshalJSONMap[appmodel.DegreeDayBase]("degree day bases", dbCfg.DegreeDayBases)
+	if err != nil {
+		return appmodel.Configuration{}, err
+	}
+	rules, err := unmarshalJSONMap[appmodel.Rule]("rules", dbCfg.Rules)
+	if err != nil {
+		return appmodel.Configuration{}, err
+	}
+	alerts, err := unmarshalJSONMap[appmodel.Alert]("alerts", dbCfg.Alerts)
+	if err != nil {
+		return appmodel.Configuration{}, err
+	}
 	return appmodel.Configuration{
 		Id:              1,
-		ApiKey:          dbCfg.APIKey,
+		ApiKey:          apiKey,
 		RefreshInterval: dbCfg.RefreshInterval,
 		RequestTimeout:  dbCfg.RequestTimeout,
 		Active:          dbCfg.Active,
 		Enable:          dbCfg.Enable,
 		ProjectIDs:      dbCfg.ProjectIds,
 		UserId:          dbCfg.UserID,
+
+		StaleAfterIntervals: dbCfg.StaleAfterIntervals,
+		MaxDataAge:          dbCfg.MaxDataAge,
+		NotifyStale:         dbCfg.NotifyStale,
+		PlausibilityLimits:  plausibilityLimits,
+		DegreeDayBases:      degreeDayBases,
+		Rules:               rules,
+		Alerts:              alerts,
 	}, nil
 }
 
+// marshalJSONMap encodes a map configuration field for its jsonb column. A nil map is stored as an empty object.
+func marshalJSONMap[V any](field string, m map[string]V) (string, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return "", fmt.Errorf("marshalling %s: %v", field, err)
+	}
+	return string(b), nil
+}
+
+// unmarshalJSONMap decodes a map configuration field from its jsonb column. An empty object gives a nil map.
+func unmarshalJSONMap[V any](field string, value string) (map[string]V, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var result map[string]V
+	if err := json.Unmarshal([]byte(value), &result); err != nil {
+		return nil, fmt.Errorf("unmarshalling %s: %v", field, err)
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
 func toAppAsset(dbAsset model.Asset) appmodel.Asset {
 	return appmodel.Asset{
 		ID:           dbAsset.ID,
@@ -283,7 +677,7 @@ func UpsertRootAsset(assetID int32, projectID, gai string) error {
 }
 
 func GetRootAssets() ([]appmodel.RootAsset, error) {
-	var assets []model.Asset
+	var assets []model.RootAsset
 	err := SELECT(
 		RootAsset.AllColumns,
 	).FROM(
@@ -296,7 +690,7 @@ func GetRootAssets() ([]appmodel.RootAsset, error) {
 	appAssets := make([]appmodel.RootAsset, 0, len(assets))
 	for _, asset := range assets {
 		appAssets = append(appAssets, appmodel.RootAsset{
-			ID:      asset.ID,
+			ID:      int64(asset.ID),
 			AssetID: asset.AssetID,
 		})
 	}
@@ -0,0 +1,85 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package dbhelper
+
+import (
+	"context"
+	"os"
+	"testing"
+	appmodel "weather-app2/app/model"
+	"weather-app2/db/generated/postgres/weather_app/model"
+	. "weather-app2/db/generated/postgres/weather_app/table"
+
+	"github.com/eliona-smart-building-assistant/go-utils/db"
+	. "github.com/go-jet/jet/v2/postgres"
+)
+
+// initTestDB connects to the database given by CONNECTION_STRING and creates the app's schema, or skips
+// the test without a database.
+func initTestDB(t *testing.T) {
+	t.Helper()
+	if db.ConnectionString() == "" {
+		t.Skip("CONNECTION_STRING is not set")
+	}
+	t.Setenv("CONFIG_ENCRYPTION_KEY", "test")
+	database := db.Database("weather-app-test")
+	schema, err := os.ReadFile("../init.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.Exec(string(schema)); err != nil {
+		t.Fatalf("creating schema: %v", err)
+	}
+	if _, err := database.Exec("delete from weather_app.configuration; delete from weather_app.configuration_history"); err != nil {
+		t.Fatalf("clearing configuration: %v", err)
+	}
+	InitDB(database)
+	t.Cleanup(func() { _ = CloseDB() })
+}
+
+func TestUpsertConfigWithoutFrontendEnvironment(t *testing.T) {
+	initTestDB(t)
+	ctx := context.Background()
+	config := appmodel.Configuration{Id: 1, ApiKey: "0123456789abcdef", RefreshInterval: 60, RequestTimeout: 60, ProjectIDs: []string{"1"}}
+	if _, err := UpsertConfig(ctx, config); err != nil {
+		t.Fatal(err)
+	}
+	// The user who saved in Eliona's frontend.
+	stmt := Configuration.UPDATE(Configuration.UserID).SET(String("42")).WHERE(Configuration.ID.EQ(Int32(1)))
+	if _, err := stmt.ExecContext(ctx, GetDB().db); err != nil {
+		t.Fatal(err)
+	}
+
+	config.RefreshInterval = 120
+	saved, err := UpsertConfig(ctx, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.UserId != "42" {
+		t.Errorf("user %q after saving without frontend environment, want the stored user 42", saved.UserId)
+	}
+	var latest model.ConfigurationHistory
+	err = ConfigurationHistory.SELECT(ConfigurationHistory.AllColumns).
+		ORDER_BY(ConfigurationHistory.ID.DESC()).
+		LIMIT(1).
+		QueryContext(ctx, GetDB().db, &latest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.UserID != "42" {
+		t.Errorf("history records user %q, want the stored user 42", latest.UserID)
+	}
+}
@@ -0,0 +1,119 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package dbhelper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	appmodel "weather-app2/app/model"
+
+	"weather-app2/db/generated/postgres/weather_app/model"
+	. "weather-app2/db/generated/postgres/weather_app/table"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+// InsertObservation stores the weather data of a location. A repeated observation time replaces the earlier data.
+func InsertObservation(ctx context.Context, observation appmodel.Observation) error {
+	data, err := json.Marshal(observation.Data)
+	if err != nil {
+		return fmt.Errorf("marshalling observation: %v", err)
+	}
+	stmt := Observation.INSERT(
+		Observation.AssetID,
+		Observation.ObservedAt,
+		Observation.Data,
+	).VALUES(
+		observation.AssetID,
+		observation.ObservedAt,
+		StringExp(CAST(String(string(data))).AS("jsonb")),
+	).ON_CONFLICT(
+		Observation.AssetID, Observation.ObservedAt,
+	).DO_UPDATE(
+		SET(Observation.Data.SET(Observation.EXCLUDED.Data)),
+	)
+	_, err = stmt.ExecContext(ctx, GetDB().db)
+	return err
+}
+
+// GetObservations returns the observations of a location in the time range [from, to), oldest first.
+func GetObservations(ctx context.Context, assetID int64, from, to time.Time) ([]appmodel.Observation, error) {
+	var observations []model.Observation
+	err := Observation.
+		SELECT(Observation.AllColumns).
+		WHERE(
+			Observation.AssetID.EQ(Int(assetID)).
+				AND(Observation.ObservedAt.GT_EQ(TimestampzT(from))).
+				AND(Observation.ObservedAt.LT(TimestampzT(to))),
+		).
+		ORDER_BY(Observation.ObservedAt.ASC()).
+		QueryContext(ctx, GetDB().db, &observations)
+	if err != nil {
+		return nil, fmt.Errorf("fetching observations: %v", err)
+	}
+
+	appObservations := make([]appmodel.Observation, 0, len(observations))
+	for _, o := range observations {
+		var data map[string]float64
+		if err := json.Unmarshal([]byte(o.Data), &data); err != nil {
+			return nil, fmt.Errorf("unmarshalling observation %d: %v", o.ID, err)
+		}
+		appObservations = append(appObservations, appmodel.Observation{
+			AssetID:    o.AssetID,
+			ObservedAt: o.ObservedAt,
+			Data:       data,
+		})
+	}
+	return appObservations, nil
+}
+
+// DeleteObservationsBefore removes observations older than the given time.
+func DeleteObservationsBefore(ctx context.Context, before time.Time) (int64, error) {
+	stmt := Observation.DELETE().WHERE(Observation.ObservedAt.LT(TimestampzT(before)))
+	result, err := stmt.ExecContext(ctx, GetDB().db)
+	if err != nil {
+		return 0, fmt.Errorf("deleting observations: %v", err)
+	}
+	return result.RowsAffected()
+}
+
+// GetObservationAttributes returns the attribute names observed for any of the locations in the time range [from, to), sorted by name.
+func GetObservationAttributes(ctx context.Context, assetIDs []int64, from, to time.Time) ([]string, error) {
+	ids := make([]Expression, 0, len(assetIDs))
+	for _, id := range assetIDs {
+		ids = append(ids, Int(id))
+	}
+	var attributes []string
+	err := SELECT(
+		Raw("DISTINCT jsonb_object_keys(observation.data)"),
+	).FROM(
+		Observation,
+	).WHERE(
+		Observation.AssetID.IN(ids...).
+			AND(Observation.ObservedAt.GT_EQ(TimestampzT(from))).
+			AND(Observation.ObservedAt.LT(TimestampzT(to))),
+	).QueryContext(ctx, GetDB().db, &attributes)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, fmt.Errorf("fetching observation attributes: %v", err)
+	}
+	slices.Sort(attributes)
+	return attributes, nil
+}
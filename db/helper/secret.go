@@ -0,0 +1,111 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package dbhelper
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+// encryptedPrefix marks values encrypted by encryptSecret. Values without it are
+// stored in plain text by earlier versions of the app and are returned unchanged.
+const encryptedPrefix = "enc:v1:"
+
+var ErrNoEncryptionKey = errors.New("environment variable CONFIG_ENCRYPTION_KEY is not set")
+
+func secretCipher() (cipher.AEAD, error) {
+	secret := common.Getenv("CONFIG_ENCRYPTION_KEY", "")
+	if secret == "" {
+		return nil, ErrNoEncryptionKey
+	}
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %v", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// CheckEncryptionKey returns ErrNoEncryptionKey if secrets can't be encrypted.
+func CheckEncryptionKey() error {
+	_, err := secretCipher()
+	return err
+}
+
+// encryptSecret encrypts a value with AES-GCM using the key from CONFIG_ENCRYPTION_KEY.
+func encryptSecret(plain string) (string, error) {
+	if plain == "" {
+		return "", nil
+	}
+	gcm, err := secretCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generating nonce: %v", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plain), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptSecret reverses encryptSecret.
+func decryptSecret(stored string) (string, error) {
+	if !strings.HasPrefix(stored, encryptedPrefix) {
+		return stored, nil
+	}
+	gcm, err := secretCipher()
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(stored, encryptedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("decoding secret: %v", err)
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("encrypted secret is too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypting secret: %v", err)
+	}
+	return string(plain), nil
+}
+
+// secretMask replaces all but the last characters of a secret returned by the API.
+const secretMask = "********"
+
+// MaskSecret hides a secret, leaving only its last four characters to recognize it.
+func MaskSecret(secret string) string {
+	if len(secret) <= 8 {
+		return secretMask
+	}
+	return secretMask + secret[len(secret)-4:]
+}
+
+// IsMaskedSecret tells whether the secret is a value returned by MaskSecret.
+func IsMaskedSecret(secret string) bool {
+	return strings.HasPrefix(secret, secretMask)
+}
@@ -47,6 +47,46 @@ create table if not exists weather_app.root_asset
 	asset_id         integer   not null unique
 );
 
+alter table weather_app.configuration add column if not exists stale_after_intervals integer not null default 3;
+alter table weather_app.configuration add column if not exists max_data_age          integer not null default 3600;
+alter table weather_app.configuration add column if not exists notify_stale          boolean not null default false;
+alter table weather_app.configuration add column if not exists plausibility_limits   jsonb   not null default '{}';
+alter table weather_app.configuration add column if not exists degree_day_bases      jsonb   not null default '{}';
+alter table weather_app.configuration add column if not exists rules                 jsonb   not null default '{}';
+alter table weather_app.configuration add column if not exists alerts                jsonb   not null default '{}';
+
+-- Every change of the configuration with the resulting values, to audit and restore them.
+create table if not exists weather_app.configuration_history
+(
+	id               bigserial   primary key,
+	changed_at       timestamptz not null default now(),
+	user_id          text        not null,
+	api_key          text        not null,
+	refresh_interval integer     not null,
+	request_timeout  integer     not null,
+	enable           boolean     not null,
+	project_ids      text[]      not null,
+	diff             jsonb       not null
+);
+
+alter table weather_app.configuration_history add column if not exists stale_after_intervals integer not null default 3;
+alter table weather_app.configuration_history add column if not exists max_data_age          integer not null default 3600;
+alter table weather_app.configuration_history add column if not exists notify_stale          boolean not null default false;
+alter table weather_app.configuration_history add column if not exists plausibility_limits   jsonb   not null default '{}';
+alter table weather_app.configuration_history add column if not exists degree_day_bases      jsonb   not null default '{}';
+alter table weather_app.configuration_history add column if not exists rules                 jsonb   not null default '{}';
+alter table weather_app.configuration_history add column if not exists alerts                jsonb   not null default '{}';
+
+-- Weather observations of each location as written to Eliona, kept for the retention period.
+create table if not exists weather_app.observation
+(
+	id          bigserial   primary key,
+	asset_id    bigint      not null references weather_app.asset(id) on delete cascade,
+	observed_at timestamptz not null,
+	data        jsonb       not null,
+	unique (asset_id, observed_at)
+);
+
 -- There is a transaction started in app.Init(). We need to commit to make the
 -- new objects available for all other init steps.
 -- Chain starts the same transaction again.
@@ -16,13 +16,15 @@
 package eliona
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	appmodel "weather-app2/app/model"
 
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"github.com/eliona-smart-building-assistant/go-eliona/asset"
 	"github.com/eliona-smart-building-assistant/go-eliona/client"
-	"github.com/eliona-smart-building-assistant/go-utils/log"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
 )
 
 var devicesCount map[int64]int
@@ -48,27 +50,101 @@ func CreateAssets(config appmodel.Configuration, assets []asset.AssetWithParentR
 	return nil
 }
 
-func notifyUser(userId string, projectId string, assetsCreated int) error {
-	receipt, _, err := client.NewClient().CommunicationAPI.
-		PostNotification(client.AuthenticationContext()).
-		Notification(
-			api.Notification{
-				User:      userId,
-				ProjectId: *api.NewNullableString(&projectId),
-				Message: *api.NewNullableTranslation(&api.Translation{
-					De: api.PtrString(fmt.Sprintf("Weather App hat %d neue Assets angelegt. Diese sind nun im Asset-Management verfügbar.", assetsCreated)),
-					En: api.PtrString(fmt.Sprintf("Wetter app added %v new assets. They are now available in Asset Management.", assetsCreated)),
-				}),
-			}).
-		Execute()
-	log.Debug("eliona", "posted notification about CAC: %v", receipt)
+func GetAsset(assetID int32) (*api.Asset, error) {
+	asset, _, err := client.NewClient().AssetsAPI.GetAssetById(client.AuthenticationContext(), assetID).Execute()
+	return asset, err
+}
+
+// DeleteAsset deletes the asset in Eliona. Assets that do not exist anymore are ignored.
+func DeleteAsset(assetID int32) error {
+	resp, err := client.NewClient().AssetsAPI.DeleteAssetById(client.AuthenticationContext(), assetID).Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// GetProjectIDs returns the IDs of all projects in Eliona.
+func GetProjectIDs() ([]string, error) {
+	projects, _, err := client.NewClient().ProjectsAPI.GetProjects(client.AuthenticationContext()).Execute()
 	if err != nil {
-		return fmt.Errorf("posting CAC notification: %v", err)
+		return nil, err
 	}
-	return nil
+	var ids []string
+	for _, project := range projects {
+		if id := project.Id.Get(); id != nil {
+			ids = append(ids, *id)
+		}
+	}
+	return ids, nil
 }
 
-func GetAsset(assetID int32) (*api.Asset, error) {
-	asset, _, err := client.NewClient().AssetsAPI.GetAssetById(client.AuthenticationContext(), assetID).Execute()
-	return asset, err
+// CheckAPI checks whether the Eliona API is reachable with the app's token.
+func CheckAPI(ctx context.Context) error {
+	_, _, err := client.NewClient().VersionAPI.GetVersion(client.AuthenticationContextWrap(ctx)).Execute()
+	return err
+}
+
+// weatherAssetTypeFile defines the weather asset type created at initialization.
+const weatherAssetTypeFile = "resources/asset-types/weather.json"
+
+// GetWeatherAttributes returns the attributes of the weather asset type as defined by the app.
+func GetWeatherAttributes() ([]api.AssetTypeAttribute, error) {
+	assetType, err := common.UnmarshalFile[api.AssetType](weatherAssetTypeFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading weather asset type: %v", err)
+	}
+	return assetType.Attributes, nil
+}
+
+// UpsertOutputAttribute adds a digital output attribute for a rule to the weather asset type.
+func UpsertOutputAttribute(name string) error {
+	return putOutputAttribute(name, true)
+}
+
+// DisableOutputAttribute disables the output attribute of a removed rule, as attributes can't be removed from
+// an asset type.
+func DisableOutputAttribute(name string) error {
+	return putOutputAttribute(name, false)
+}
+
+func putOutputAttribute(name string, enable bool) error {
+	_, _, err := client.NewClient().AssetTypesAPI.
+		PutAssetTypeAttribute(client.AuthenticationContext(), WeatherAssetType).
+		AssetTypeAttribute(api.AssetTypeAttribute{
+			Name:    name,
+			Subtype: api.SUBTYPE_OUTPUT,
+			Enable:  api.PtrBool(enable),
+			Translation: *api.NewNullableTranslation(&api.Translation{
+				De: api.PtrString(name),
+				En: api.PtrString(name),
+			}),
+			IsDigital: *api.NewNullableBool(api.PtrBool(true)),
+			Min:       *api.NewNullableFloat64(api.PtrFloat64(0)),
+			Max:       *api.NewNullableFloat64(api.PtrFloat64(1)),
+			Map: []map[string]any{
+				{"value": 0, "map": "Off"},
+				{"value": 1, "map": "On"},
+			},
+		}).Execute()
+	return err
+}
+
+// GetOutputAttributes returns the enabled output attributes of the weather asset type in Eliona. The asset type
+// defines no outputs itself, so these are the outputs of rules.
+func GetOutputAttributes() ([]string, error) {
+	assetType, _, err := client.NewClient().AssetTypesAPI.
+		GetAssetTypeByName(client.AuthenticationContext(), WeatherAssetType).
+		Expansions([]string{"AssetType.attributes"}).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+	var outputs []string
+	for _, attribute := range assetType.Attributes {
+		if attribute.Subtype == api.SUBTYPE_OUTPUT && attribute.GetEnable() {
+			outputs = append(outputs, attribute.Name)
+		}
+	}
+	return outputs, nil
 }
@@ -18,9 +18,11 @@ package eliona
 import (
 	"fmt"
 	"time"
+	"weather-app2/metrics"
 
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-eliona/client"
 )
 
 const ClientReference string = "weather-app2"
@@ -37,7 +39,54 @@ func UpsertData(assetID int32, assetData map[string]any, timestamp time.Time, su
 		// AssetTypeName: api.NullableString{}, No need to fill, it's only for selection
 	}
 	if err := asset.UpsertDataIfAssetExists(data); err != nil {
+		metrics.UpsertError()
 		return fmt.Errorf("upserting data: %v", err)
 	}
 	return nil
 }
+
+// UpsertDataBulk upserts data of the same subtype for several assets at once.
+func UpsertDataBulk(assetData map[int32]map[string]any, timestamp time.Time, subtype api.DataSubtype) error {
+	cr := ClientReference
+
+	data := make([]api.Data, 0, len(assetData))
+	for assetID, d := range assetData {
+		data = append(data, api.Data{
+			AssetId:         assetID,
+			Subtype:         subtype,
+			Timestamp:       *api.NewNullableTime(&timestamp),
+			Data:            d,
+			ClientReference: *api.NewNullableString(&cr),
+		})
+	}
+	if err := asset.UpsertDataBulkIfAssetExists(data); err != nil {
+		metrics.UpsertError()
+		return fmt.Errorf("upserting data bulk: %v", err)
+	}
+	return nil
+}
+
+// GetAssetTypeData returns the current data of the given subtype for all assets of the given asset type.
+func GetAssetTypeData(assetType string, subtype api.DataSubtype) ([]api.Data, error) {
+	data, _, err := client.NewClient().DataAPI.
+		GetData(client.AuthenticationContext()).
+		AssetTypeName(assetType).
+		DataSubtype(string(subtype)).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf("getting %s data for asset type %s: %v", subtype, assetType, err)
+	}
+	return data, nil
+}
+
+// GetAssetData returns the current data of the given subtype for an asset, or nil if there is none.
+func GetAssetData(assetID int32, subtype api.DataSubtype) (*api.Data, error) {
+	data, err := asset.GetData(assetID, string(subtype))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return &data[0], nil
+}
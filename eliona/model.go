@@ -17,6 +17,7 @@ package eliona
 
 import (
 	"context"
+	"fmt"
 	appmodel "weather-app2/app/model"
 	conf "weather-app2/db/helper"
 )
@@ -59,3 +60,58 @@ func (r *Root) GetLocationalParentGAI() string {
 func (r *Root) GetFunctionalParentGAI() string {
 	return r.FunctionalParentGAI
 }
+
+const WeatherAssetType = "weather_app_weather"
+
+// Weather is an asset providing weather for a location. The location is
+// stored together with the asset ID as soon as the asset is created.
+type Weather struct {
+	Identifier   string
+	Name         string
+	LocationName string
+	Lat          float64
+	Lon          float64
+
+	LocationalParentGAI string
+	FunctionalParentGAI string
+
+	AssetID int32
+}
+
+func (w *Weather) GetName() string {
+	if w.Name != "" {
+		return w.Name
+	}
+	return w.LocationName
+}
+
+func (w *Weather) GetDescription() string {
+	return fmt.Sprintf("Weather for %s", w.LocationName)
+}
+
+func (w *Weather) GetAssetType() string {
+	return WeatherAssetType
+}
+
+func (w *Weather) GetGAI() string {
+	return fmt.Sprintf("%s_%s", w.GetAssetType(), w.Identifier)
+}
+
+func (w *Weather) SetAssetID(assetID int32, projectID string) error {
+	w.AssetID = assetID
+	return conf.InsertAsset(context.Background(), appmodel.Asset{
+		ProjectID:    projectID,
+		AssetID:      assetID,
+		LocationName: w.LocationName,
+		Lat:          w.Lat,
+		Lon:          w.Lon,
+	})
+}
+
+func (w *Weather) GetLocationalParentGAI() string {
+	return w.LocationalParentGAI
+}
+
+func (w *Weather) GetFunctionalParentGAI() string {
+	return w.FunctionalParentGAI
+}
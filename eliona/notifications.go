@@ -0,0 +1,122 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	appmodel "weather-app2/app/model"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona/client"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// notify posts a notification to the user in the project. about names the notification in logs and errors.
+func notify(userId string, projectId string, message api.Translation, about string) error {
+	receipt, _, err := client.NewClient().CommunicationAPI.
+		PostNotification(client.AuthenticationContext()).
+		Notification(
+			api.Notification{
+				User:      userId,
+				ProjectId: *api.NewNullableString(&projectId),
+				Message:   *api.NewNullableTranslation(&message),
+			}).
+		Execute()
+	log.Debug("eliona", "posted notification about %s: %v", about, receipt)
+	if err != nil {
+		return fmt.Errorf("posting %s notification: %v", about, err)
+	}
+	return nil
+}
+
+func notifyUser(userId string, projectId string, assetsCreated int) error {
+	return notify(userId, projectId, api.Translation{
+		De: api.PtrString(fmt.Sprintf("Weather App hat %d neue Assets angelegt. Diese sind nun im Asset-Management verfügbar.", assetsCreated)),
+		En: api.PtrString(fmt.Sprintf("Wetter app added %v new assets. They are now available in Asset Management.", assetsCreated)),
+		Fr: api.PtrString(fmt.Sprintf("Weather App a créé %d nouveaux assets. Ils sont maintenant disponibles dans la gestion des assets.", assetsCreated)),
+		It: api.PtrString(fmt.Sprintf("Weather App ha creato %d nuovi asset. Sono ora disponibili nella gestione degli asset.", assetsCreated)),
+	}, "CAC")
+}
+
+// NotifyStaleData tells the user that the weather of a location is not up to date anymore.
+func NotifyStaleData(userId string, projectId string, locationName string, reason string) error {
+	return notify(userId, projectId, api.Translation{
+		De: api.PtrString(fmt.Sprintf("Weather App: Die Wetterdaten für %s sind veraltet (%s).", locationName, reason)),
+		En: api.PtrString(fmt.Sprintf("Weather app: The weather data for %s is stale (%s).", locationName, reason)),
+		Fr: api.PtrString(fmt.Sprintf("Weather App : Les données météo pour %s ne sont plus à jour (%s).", locationName, reason)),
+		It: api.PtrString(fmt.Sprintf("Weather App: I dati meteo per %s non sono aggiornati (%s).", locationName, reason)),
+	}, "stale data")
+}
+
+// ThresholdAlert is an alert whose threshold a weather attribute of a location crossed, or came back from.
+type ThresholdAlert struct {
+	Name         string
+	Alert        appmodel.Alert
+	LocationName string
+	// Attribute is the watched attribute of the weather asset type, for its translated name and unit.
+	Attribute api.AssetTypeAttribute
+	Value     float64
+	Resolved  bool
+}
+
+// NotifyThresholdAlert tells the user that an alert was triggered or resolved.
+func NotifyThresholdAlert(userId string, projectId string, alert ThresholdAlert) error {
+	return notify(userId, projectId, thresholdAlertMessage(alert), "alert "+alert.Name)
+}
+
+func thresholdAlertMessage(alert ThresholdAlert) api.Translation {
+	translation := alert.Attribute.GetTranslation()
+	label := func(translated *string) string {
+		if translated == nil || *translated == "" {
+			return alert.Attribute.Name
+		}
+		return *translated
+	}
+	unit := alert.Attribute.GetUnit()
+	threshold := formatValue(alert.Alert.Threshold, unit)
+	value := formatValue(alert.Value, unit)
+	below := alert.Alert.Condition == appmodel.RuleBelow
+
+	if alert.Resolved {
+		return api.Translation{
+			De: api.PtrString(fmt.Sprintf("Weather App: Warnung „%s“ aufgehoben, %s bei %s liegt wieder bei %s.", alert.Name, label(translation.De), alert.LocationName, value)),
+			En: api.PtrString(fmt.Sprintf("Weather app: Alert \"%s\" resolved, %s at %s is back at %s.", alert.Name, label(translation.En), alert.LocationName, value)),
+			Fr: api.PtrString(fmt.Sprintf("Weather App : Alerte « %s » levée, %s à %s est de nouveau à %s.", alert.Name, label(translation.Fr), alert.LocationName, value)),
+			It: api.PtrString(fmt.Sprintf("Weather App: Avviso \"%s\" rientrato, %s a %s è di nuovo a %s.", alert.Name, label(translation.It), alert.LocationName, value)),
+		}
+	}
+	de, en, fr, it := "über", "above", "supérieur à", "sopra"
+	if below {
+		de, en, fr, it = "unter", "below", "inférieur à", "sotto"
+	}
+	return api.Translation{
+		De: api.PtrString(fmt.Sprintf("Weather App: Warnung „%s“, %s bei %s liegt %s %s (aktuell %s).", alert.Name, label(translation.De), alert.LocationName, de, threshold, value)),
+		En: api.PtrString(fmt.Sprintf("Weather app: Alert \"%s\", %s at %s is %s %s (currently %s).", alert.Name, label(translation.En), alert.LocationName, en, threshold, value)),
+		Fr: api.PtrString(fmt.Sprintf("Weather App : Alerte « %s », %s à %s est %s %s (actuellement %s).", alert.Name, label(translation.Fr), alert.LocationName, fr, threshold, value)),
+		It: api.PtrString(fmt.Sprintf("Weather App: Avviso \"%s\", %s a %s è %s %s (attualmente %s).", alert.Name, label(translation.It), alert.LocationName, it, threshold, value)),
+	}
+}
+
+// formatValue rounds the value to one decimal and appends the unit.
+func formatValue(value float64, unit string) string {
+	formatted := strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64)
+	if unit == "" {
+		return formatted
+	}
+	return formatted + " " + unit
+}
@@ -1,21 +1,68 @@
 package eliona
 
 import (
+	"sync/atomic"
 	"time"
+	"weather-app2/metrics"
 
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
 	"github.com/eliona-smart-building-assistant/go-utils/common"
 	"github.com/eliona-smart-building-assistant/go-utils/http"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
 	"github.com/gorilla/websocket"
 )
 
-// ListenForPropertyChanges on assets (only output attributes). Returns a channel with all changes.
-func ListenForPropertyChanges() (chan api.Data, error) {
+// ListenForPropertyChanges on assets (only property attributes). Returns a channel with all changes.
+// The onConnect function is called every time the websocket is (re)connected, before any change is
+// read from it. This allows the caller to catch up on changes made while nobody was listening.
+func ListenForPropertyChanges(onConnect func()) (chan api.Data, error) {
 	outputs := make(chan api.Data)
-	go http.ListenWebSocketWithReconnectAlways(newWebsocket, time.Duration(0), outputs)
+	go listenWebSocketWithReconnect(onConnect, outputs)
 	return outputs, nil
 }
 
+// websocketConnected tells whether the property change listener is currently connected.
+var websocketConnected atomic.Bool
+
+// WebsocketConnected returns true while the property change listener is connected to Eliona.
+func WebsocketConnected() bool {
+	return websocketConnected.Load()
+}
+
+// Bounds of the delay before reconnecting the websocket. The delay doubles with every connection that drops
+// quickly, so that a flapping websocket doesn't run the catch-up in onConnect in a loop.
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = time.Minute
+)
+
+func listenWebSocketWithReconnect(onConnect func(), outputs chan api.Data) {
+	// If this method returns, signal that no further values will be sent.
+	defer close(outputs)
+	reconnectDelay := minReconnectDelay
+	for {
+		conn, err := newWebsocket()
+		if err != nil {
+			log.Error("websocket", "Error creating web socket: %v", err)
+			return
+		}
+		websocketConnected.Store(true)
+		connectedAt := time.Now()
+		onConnect()
+		if err := http.ListenWebSocket(conn, outputs); err != nil {
+			log.Debug("websocket", "Reconnecting web socket: %v", err)
+		}
+		websocketConnected.Store(false)
+		_ = conn.Close()
+		metrics.WebsocketReconnect()
+		if time.Since(connectedAt) > maxReconnectDelay {
+			reconnectDelay = minReconnectDelay
+		}
+		time.Sleep(reconnectDelay)
+		reconnectDelay = min(2*reconnectDelay, maxReconnectDelay)
+	}
+}
+
 func newWebsocket() (*websocket.Conn, error) {
 	return http.NewWebSocketConnectionWithApiKey(common.Getenv("API_ENDPOINT", "")+"/data-listener?dataSubtype=property", "X-API-Key", common.Getenv("API_TOKEN", ""))
 }
@@ -18,5 +18,5 @@ func TestApp(t *testing.T) {
 func schema(t *testing.T) {
 	t.Parallel()
 
-	assert.SchemaExists(t, "weather_app", []string{"configuration", "asset"})
+	assert.SchemaExists(t, "weather_app", []string{"configuration", "configuration_history", "asset", "observation"})
 }
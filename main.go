@@ -48,6 +48,7 @@ func main() {
 		app.ListenApi,
 		app.ListenForOutputChanges,
 		common.Loop(app.Heartbeat, 2*time.Minute),
+		common.Loop(app.UpdateSolarPosition, time.Minute),
 	)
 
 	log.Info("main", "Terminate the app.")
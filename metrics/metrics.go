@@ -0,0 +1,157 @@
+//  This file is part of the Eliona project.
+//  Copyright © 2025 IoTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package metrics collects the app's Prometheus metrics and serves them on /metrics.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "weather_app"
+
+var (
+	providerRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "provider_requests_total",
+		Help:      "Requests sent to OpenWeatherMap by endpoint and HTTP status. Status is \"error\" if no response was received.",
+	}, []string{"endpoint", "status"})
+
+	providerRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "provider_request_duration_seconds",
+		Help:      "Latency of requests to OpenWeatherMap by endpoint and HTTP status, as for provider_requests_total.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"endpoint", "status"})
+
+	// The quota is read when scraped, so that it drops to 0 at midnight UTC and not only with the next request.
+	providerQuotaUsed = promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "provider_quota_used",
+		Help:      "One Call requests sent to OpenWeatherMap since midnight UTC, which count against the daily quota.",
+	}, func() float64 { return float64(QuotaUsed()) })
+
+	cycleDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "cycle_duration_seconds",
+		Help:      "Duration of a data collection cycle.",
+		Buckets:   []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	})
+
+	assetsProcessed = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "assets_processed_total",
+		Help:      "Weather assets updated successfully.",
+	})
+
+	assetsFailed = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "assets_failed_total",
+		Help:      "Weather assets that could not be updated.",
+	})
+
+	upsertErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "eliona_upsert_errors_total",
+		Help:      "Failed writes of data to Eliona.",
+	})
+
+	websocketReconnects = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "websocket_reconnects_total",
+		Help:      "Reconnects of the websocket listening for location changes.",
+	})
+
+	appStatus = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "app_status",
+		Help:      "Status of the app as written to the root asset: 0 OK, 1 error, 2 fatal.",
+	})
+)
+
+// quota counts the One Call requests of the current UTC day.
+var quota struct {
+	sync.Mutex
+	day   time.Time
+	count int
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveProviderRequest records a request to the provider. A status code of 0 means that no response was received.
+func ObserveProviderRequest(endpoint string, statusCode int, duration time.Duration) {
+	status := "error"
+	if statusCode != 0 {
+		status = strconv.Itoa(statusCode)
+	}
+	providerRequests.WithLabelValues(endpoint, status).Inc()
+	providerRequestDuration.WithLabelValues(endpoint, status).Observe(duration.Seconds())
+}
+
+// CountQuotaRequest counts a request against the provider's daily quota.
+func CountQuotaRequest() {
+	quota.Lock()
+	defer quota.Unlock()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if !quota.day.Equal(today) {
+		quota.day = today
+		quota.count = 0
+	}
+	quota.count++
+}
+
+// QuotaUsed returns the requests counted against today's quota.
+func QuotaUsed() int {
+	quota.Lock()
+	defer quota.Unlock()
+	if !quota.day.Equal(time.Now().UTC().Truncate(24 * time.Hour)) {
+		return 0
+	}
+	return quota.count
+}
+
+func ObserveCycle(duration time.Duration) {
+	cycleDuration.Observe(duration.Seconds())
+}
+
+func AssetProcessed() {
+	assetsProcessed.Inc()
+}
+
+func AssetFailed() {
+	assetsFailed.Inc()
+}
+
+func UpsertError() {
+	upsertErrors.Inc()
+}
+
+func WebsocketReconnect() {
+	websocketReconnects.Inc()
+}
+
+func SetAppStatus(status int) {
+	appStatus.Set(float64(status))
+}